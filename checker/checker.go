@@ -23,3 +23,39 @@ type Checker interface {
 	Identity() (string, error)
 	Check(ctx context.Context) error
 }
+
+// Named is the interface implemented by the checkers which have a human-friendly name
+// and labels, which are used instead of the type name in logs, errors and results.
+type Named interface {
+	// Name returns the name of the checker.
+	Name() string
+	// Labels returns the key/value labels of the checker.
+	Labels() map[string]string
+}
+
+// labeledChecker attaches a name and labels to a checker.
+type labeledChecker struct {
+	Checker
+	name   string
+	labels map[string]string
+}
+
+// WithLabels wraps the checker with the given name and key/value labels.
+func WithLabels(chk Checker, name string, labels map[string]string) Checker {
+	return &labeledChecker{Checker: chk, name: name, labels: labels}
+}
+
+// Name returns the name of the checker
+func (lc *labeledChecker) Name() string {
+	return lc.name
+}
+
+// Labels returns the labels of the checker
+func (lc *labeledChecker) Labels() map[string]string {
+	return lc.labels
+}
+
+// Unwrap returns the wrapped checker
+func (lc *labeledChecker) Unwrap() Checker {
+	return lc.Checker
+}
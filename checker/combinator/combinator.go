@@ -0,0 +1,293 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package combinator provides checkers which decorate or combine other checkers.
+package combinator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"wait4x.dev/v3/checker"
+)
+
+// allChecker passes when all of its checkers pass.
+type allChecker struct {
+	checkers []checker.Checker
+}
+
+// All creates a checker which passes when all of the given checkers pass. The checkers are
+// checked in order, and the first failure stops the check.
+func All(checkers ...checker.Checker) checker.Checker {
+	return &allChecker{checkers: checkers}
+}
+
+// Identity returns the identity of the checker
+func (a *allChecker) Identity() (string, error) {
+	return compositeIdentity("all", a.checkers)
+}
+
+// Check checks all of the checkers
+func (a *allChecker) Check(ctx context.Context) error {
+	for _, chk := range a.checkers {
+		if err := chk.Check(ctx); err != nil {
+			return wrapError(chk, err)
+		}
+	}
+
+	return nil
+}
+
+// anyChecker passes when one of its checkers passes.
+type anyChecker struct {
+	checkers []checker.Checker
+}
+
+// Any creates a checker which passes when any of the given checkers passes. The checkers are
+// checked in order, and the first success stops the check.
+func Any(checkers ...checker.Checker) checker.Checker {
+	return &anyChecker{checkers: checkers}
+}
+
+// Identity returns the identity of the checker
+func (a *anyChecker) Identity() (string, error) {
+	return compositeIdentity("any", a.checkers)
+}
+
+// Check checks the checkers until one of them passes. The failure is permanent only when
+// all of the checkers failed permanently, and it keeps the details of the failed checkers.
+func (a *anyChecker) Check(ctx context.Context) error {
+	errs := make([]error, 0, len(a.checkers))
+	var details []any
+	permanent := true
+	for _, chk := range a.checkers {
+		err := chk.Check(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		errs = append(errs, wrapError(chk, err))
+		details = append(details, checkerDetails(chk, err)...)
+		permanent = permanent && checker.IsPermanentError(err)
+	}
+
+	return &anyError{
+		err:       checker.NewExpectedError("none of the checks passed", errors.Join(errs...), details...),
+		permanent: permanent,
+	}
+}
+
+// anyError is the error of the Any checker, it decides whether the failure is permanent instead of
+// the errors of the checkers.
+type anyError struct {
+	err       error
+	permanent bool
+}
+
+// Permanent reports whether all of the checkers failed permanently
+func (ae *anyError) Permanent() bool {
+	return ae.permanent
+}
+
+func (ae *anyError) Unwrap() error {
+	return ae.err
+}
+
+func (ae *anyError) Error() string {
+	return ae.err.Error()
+}
+
+// notChecker inverts the result of its checker.
+type notChecker struct {
+	checker checker.Checker
+}
+
+// Not creates a checker which passes when the given checker fails, and fails when it passes.
+func Not(chk checker.Checker) checker.Checker {
+	return &notChecker{checker: chk}
+}
+
+// Identity returns the identity of the checker
+func (n *notChecker) Identity() (string, error) {
+	return compositeIdentity("not", []checker.Checker{n.checker})
+}
+
+// Check checks the checker and inverts its result. A permanent error, e.g. a malformed DSN,
+// isn't inverted, since it doesn't tell anything about the target.
+func (n *notChecker) Check(ctx context.Context) error {
+	if err := n.checker.Check(ctx); err != nil {
+		// A check interrupted by the context doesn't tell anything about the checker
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if checker.IsPermanentError(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	id, err := n.checker.Identity()
+	if err != nil {
+		return err
+	}
+
+	return checker.NewExpectedError(fmt.Sprintf("the %s check passed unexpectedly", id), nil)
+}
+
+// timeoutChecker bounds the duration of its checker.
+type timeoutChecker struct {
+	checker checker.Checker
+	timeout time.Duration
+}
+
+// WithTimeout creates a checker which fails when the given checker doesn't finish in the timeout.
+func WithTimeout(chk checker.Checker, timeout time.Duration) checker.Checker {
+	return &timeoutChecker{checker: chk, timeout: timeout}
+}
+
+// Identity returns the identity of the checker
+func (t *timeoutChecker) Identity() (string, error) {
+	return t.checker.Identity()
+}
+
+// Check checks the checker within the timeout
+func (t *timeoutChecker) Check(ctx context.Context) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	err := t.checker.Check(timeoutCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		details := append([]any{"timeout", t.timeout}, checkerDetails(t.checker, err)...)
+		return checker.NewExpectedError("timed out while checking", err, details...)
+	}
+
+	return err
+}
+
+// WithName creates a checker named by the given name, see checker.Named. It keeps the identity
+// and the labels of the given checker, use checker.WithLabels to set the labels as well.
+func WithName(chk checker.Checker, name string) checker.Checker {
+	var labels map[string]string
+	if named, ok := chk.(checker.Named); ok {
+		labels = named.Labels()
+	}
+
+	return checker.WithLabels(chk, name, labels)
+}
+
+// retryChecker retries its checker within a single check.
+type retryChecker struct {
+	checker  checker.Checker
+	attempts int
+	delay    time.Duration
+}
+
+// Retry creates a checker which checks the given checker up to the given number of attempts,
+// waiting for the delay between them, and passes as soon as one of the attempts passes.
+// It's useful to tolerate flaky parts of a composed checker. At least one attempt is made.
+func Retry(chk checker.Checker, attempts int, delay time.Duration) checker.Checker {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return &retryChecker{checker: chk, attempts: attempts, delay: delay}
+}
+
+// Identity returns the identity of the checker
+func (r *retryChecker) Identity() (string, error) {
+	return r.checker.Identity()
+}
+
+// Check checks the checker until one of the attempts passes, or it fails permanently
+func (r *retryChecker) Check(ctx context.Context) error {
+	var err error
+	for attempt := 1; attempt <= r.attempts; attempt++ {
+		if err = r.checker.Check(ctx); err == nil {
+			return nil
+		}
+
+		if attempt == r.attempts || checker.IsPermanentError(err) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.delay):
+		}
+	}
+
+	return err
+}
+
+// compositeIdentity returns the identity of a combination of checkers, e.g. all(a, b).
+func compositeIdentity(name string, checkers []checker.Checker) (string, error) {
+	ids := make([]string, len(checkers))
+	for i, chk := range checkers {
+		id, err := chk.Identity()
+		if err != nil {
+			return "", err
+		}
+
+		ids[i] = id
+	}
+
+	return fmt.Sprintf("%s(%s)", name, strings.Join(ids, ", ")), nil
+}
+
+// wrapError prefixes the error of a checker with its identity, and keeps the details of an ExpectedError.
+func wrapError(chk checker.Checker, err error) error {
+	id, idErr := chk.Identity()
+	if idErr != nil {
+		return err
+	}
+
+	var expectedError *checker.ExpectedError
+	if errors.As(err, &expectedError) {
+		return checker.NewExpectedError(fmt.Sprintf("the %s check failed", id), err, expectedError.Details()...)
+	}
+
+	return fmt.Errorf("%s: %w", id, err)
+}
+
+// checkerDetails returns the details of the ExpectedError of a checker, the keys are prefixed with
+// the identity of the checker, e.g. http://localhost.actual.
+func checkerDetails(chk checker.Checker, err error) []any {
+	var expectedError *checker.ExpectedError
+	if !errors.As(err, &expectedError) {
+		return nil
+	}
+
+	details := expectedError.Details()
+	id, idErr := chk.Identity()
+	if idErr != nil {
+		return details
+	}
+
+	prefixed := make([]any, 0, len(details))
+	for i := 0; i+1 < len(details); i += 2 {
+		prefixed = append(prefixed, fmt.Sprintf("%s.%v", id, details[i]), details[i+1])
+	}
+
+	return prefixed
+}
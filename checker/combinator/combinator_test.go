@@ -0,0 +1,176 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wait4x.dev/v3/checker"
+)
+
+func newMockChecker(id string, err error) *checker.MockChecker {
+	chk := new(checker.MockChecker)
+	chk.On("Check", mock.Anything).Return(err).
+		On("Identity").Return(id, nil)
+
+	return chk
+}
+
+func TestAll(t *testing.T) {
+	expectedErr := checker.NewExpectedError("unexpected status code", nil, "actual", 500)
+
+	first := newMockChecker("tcp", nil)
+	second := newMockChecker("http", expectedErr)
+	third := newMockChecker("redis", nil)
+
+	chk := All(first, second, third)
+
+	id, err := chk.Identity()
+	assert.NoError(t, err)
+	assert.Equal(t, "all(tcp, http, redis)", id)
+
+	err = chk.Check(context.Background())
+	assert.ErrorIs(t, err, expectedErr)
+	assert.EqualError(t, err, "the http check failed, caused by: unexpected status code")
+
+	var ee *checker.ExpectedError
+	assert.ErrorAs(t, err, &ee)
+	assert.Equal(t, []any{"actual", 500}, ee.Details())
+
+	third.AssertNotCalled(t, "Check", mock.Anything)
+	assert.NoError(t, All(first, third).Check(context.Background()))
+}
+
+func TestAny(t *testing.T) {
+	first := newMockChecker("primary", fmt.Errorf("error"))
+	second := newMockChecker("replica", nil)
+
+	chk := Any(first, second)
+
+	id, err := chk.Identity()
+	assert.NoError(t, err)
+	assert.Equal(t, "any(primary, replica)", id)
+	assert.NoError(t, chk.Check(context.Background()))
+
+	err = Any(first, first).Check(context.Background())
+	var ee *checker.ExpectedError
+	assert.ErrorAs(t, err, &ee)
+	assert.EqualError(t, err, "none of the checks passed, caused by: primary: error\nprimary: error")
+
+	// The details of the failed checkers are kept
+	unavailable := newMockChecker("http://primary", checker.NewExpectedError("the status code doesn't expect", nil, "actual", 503))
+	refused := newMockChecker("replica:5432", checker.NewExpectedError("failed to establish a tcp connection", nil, "address", "replica:5432"))
+	err = Any(unavailable, first, refused).Check(context.Background())
+	assert.ErrorAs(t, err, &ee)
+	assert.Equal(t, []any{"http://primary.actual", 503, "replica:5432.address", "replica:5432"}, ee.Details())
+}
+
+func TestNot(t *testing.T) {
+	passing := newMockChecker("redis", nil)
+
+	chk := Not(passing)
+
+	id, err := chk.Identity()
+	assert.NoError(t, err)
+	assert.Equal(t, "not(redis)", id)
+	assert.EqualError(t, chk.Check(context.Background()), "the redis check passed unexpectedly")
+
+	failing := newMockChecker("redis", checker.NewExpectedError("key doesn't exist", nil))
+	assert.NoError(t, Not(failing).Check(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, Not(failing).Check(ctx), context.Canceled)
+}
+
+func TestWithTimeout(t *testing.T) {
+	slow := new(checker.MockChecker)
+	slow.On("Check", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(checker.NewExpectedError("failed to read the response", context.DeadlineExceeded, "read", 42)).
+		On("Identity").Return("slow", nil)
+
+	chk := WithTimeout(slow, 10*time.Millisecond)
+
+	id, err := chk.Identity()
+	assert.NoError(t, err)
+	assert.Equal(t, "slow", id)
+
+	err = chk.Check(context.Background())
+	var ee *checker.ExpectedError
+	assert.ErrorAs(t, err, &ee)
+	assert.Equal(t, []any{"timeout", 10 * time.Millisecond, "slow.read", 42}, ee.Details())
+}
+
+func TestWithName(t *testing.T) {
+	chk := WithName(All(newMockChecker("tcp", nil), newMockChecker("http", nil)), "api")
+
+	id, err := chk.Identity()
+	assert.NoError(t, err)
+	assert.Equal(t, "all(tcp, http)", id)
+	assert.NoError(t, chk.Check(context.Background()))
+
+	named, ok := chk.(checker.Named)
+	assert.True(t, ok)
+	assert.Equal(t, "api", named.Name())
+
+	// The labels of a labeled checker are kept
+	labeled := WithName(checker.WithLabels(newMockChecker("tcp", nil), "db", map[string]string{"team": "payments"}), "primary")
+	assert.Equal(t, "primary", labeled.(checker.Named).Name())
+	assert.Equal(t, map[string]string{"team": "payments"}, labeled.(checker.Named).Labels())
+}
+
+func TestRetry(t *testing.T) {
+	flaky := new(checker.MockChecker)
+	flaky.On("Check", mock.Anything).Return(errors.New("error")).Twice().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("flaky", nil)
+
+	assert.NoError(t, Retry(flaky, 3, time.Millisecond).Check(context.Background()))
+	flaky.AssertNumberOfCalls(t, "Check", 3)
+
+	failing := newMockChecker("failing", errors.New("error"))
+	assert.EqualError(t, Retry(failing, 2, time.Millisecond).Check(context.Background()), "error")
+	failing.AssertNumberOfCalls(t, "Check", 2)
+
+	assert.Error(t, Retry(failing, 0, time.Millisecond).Check(context.Background()))
+	failing.AssertNumberOfCalls(t, "Check", 3)
+}
+
+func TestPermanentError(t *testing.T) {
+	permanent := newMockChecker("mysql", checker.NewAuthenticationError("failed to authenticate to the mysql server", nil))
+	retryable := newMockChecker("replica", errors.New("error"))
+
+	assert.False(t, checker.IsPermanentError(All(retryable, permanent).Check(context.Background())))
+	assert.True(t, checker.IsPermanentError(All(permanent, retryable).Check(context.Background())))
+
+	assert.False(t, checker.IsPermanentError(Any(permanent, retryable).Check(context.Background())))
+	assert.True(t, checker.IsPermanentError(Any(permanent, permanent).Check(context.Background())))
+
+	// The permanent errors aren't inverted
+	invalid := checker.NewPermanentError(errors.New("invalid redis URL scheme"))
+	assert.Equal(t, invalid, Not(newMockChecker("redis", invalid)).Check(context.Background()))
+
+	malformed := newMockChecker("redis", checker.NewPermanentError(errors.New("invalid redis URL scheme")))
+	err := Retry(malformed, 3, time.Millisecond).Check(context.Background())
+	assert.True(t, checker.IsPermanentError(err))
+	malformed.AssertNumberOfCalls(t, "Check", 1)
+}
@@ -50,3 +50,85 @@ func (ee *ExpectedError) Error() string {
 
 	return ee.msg
 }
+
+// AuthenticationError is returned by the checkers when the target rejected the credentials
+type AuthenticationError struct {
+	msg   string
+	cause error
+}
+
+// NewAuthenticationError creates the AuthenticationError
+func NewAuthenticationError(msg string, cause error) error {
+	return &AuthenticationError{
+		msg:   msg,
+		cause: cause,
+	}
+}
+
+// Permanent reports that the rejected credentials aren't accepted by retrying
+func (ae *AuthenticationError) Permanent() bool {
+	return true
+}
+
+func (ae *AuthenticationError) Unwrap() error {
+	return ae.cause
+}
+
+func (ae *AuthenticationError) Error() string {
+	if ae.cause != nil {
+		return fmt.Sprintf("%s, caused by: %s", ae.msg, ae.cause.Error())
+	}
+
+	return ae.msg
+}
+
+// PermanentError marks an error which retrying can't resolve, e.g. a malformed DSN or an unknown database
+type PermanentError struct {
+	err error
+}
+
+// NewPermanentError creates the PermanentError
+func NewPermanentError(err error) error {
+	return &PermanentError{
+		err: err,
+	}
+}
+
+// Permanent reports that the error is permanent
+func (pe *PermanentError) Permanent() bool {
+	return true
+}
+
+func (pe *PermanentError) Unwrap() error {
+	return pe.err
+}
+
+func (pe *PermanentError) Error() string {
+	return pe.err.Error()
+}
+
+// IsPermanentError reports whether the error can't be resolved by retrying the check.
+// The first error in the tree implementing the Permanent() bool method decides, so a
+// combination of errors can tell whether it's permanent as a whole.
+func IsPermanentError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if p, ok := err.(interface{ Permanent() bool }); ok {
+		return p.Permanent()
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return IsPermanentError(e.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if IsPermanentError(err) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
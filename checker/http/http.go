@@ -185,7 +185,8 @@ func (h *HTTP) Identity() (string, error) {
 func (h *HTTP) Check(ctx context.Context) (err error) {
 	tlsConfig, err := h.getTLSConfig()
 	if err != nil {
-		return
+		// The CA, certificate or key files are invalid
+		return checker.NewPermanentError(err)
 	}
 	httpClient := &http.Client{
 		Timeout: h.timeout,
@@ -208,13 +209,21 @@ func (h *HTTP) Check(ctx context.Context) (err error) {
 
 	req, err := http.NewRequestWithContext(ctx, method, h.address, h.requestBody)
 	if err != nil {
-		return err
+		// The address is malformed
+		return checker.NewPermanentError(err)
 	}
 
 	req.Header = h.requestHeaders
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
+		if checker.IsTLSVerificationError(err) {
+			return checker.NewPermanentError(checker.NewExpectedError(
+				"failed to verify the certificate of the http server", err,
+				"address", h.address,
+			))
+		}
+
 		if os.IsTimeout(err) {
 			return checker.NewExpectedError(
 				"timed out while making an http call", err,
@@ -79,6 +79,17 @@ func TestHttpInvalidTLS(t *testing.T) {
 	assert.Nil(t, hc.Check(context.TODO()))
 }
 
+func TestHttpUntrustedCertificate(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	err := New(ts.URL).Check(context.TODO())
+	assert.True(t, checker.IsPermanentError(err))
+	assert.ErrorContains(t, err, "failed to verify the certificate of the http server")
+
+	assert.Nil(t, New(ts.URL, WithInsecureSkipTLSVerify(true)).Check(context.TODO()))
+}
+
 func TestHttpNoRedirect(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Location", "https://wait4x.dev")
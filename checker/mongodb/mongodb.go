@@ -21,6 +21,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 	"regexp"
 	"strings"
@@ -58,7 +59,8 @@ func (m *MongoDB) Check(ctx context.Context) (err error) {
 	// Creates a new Client and then initializes it using the Connect method.
 	c, err := mongo.Connect(ctx, options.Client().ApplyURI(m.dsn))
 	if err != nil {
-		return err
+		// The DSN is malformed, the connection is established lazily
+		return checker.NewPermanentError(err)
 	}
 
 	defer func(c *mongo.Client, ctx context.Context) {
@@ -70,6 +72,14 @@ func (m *MongoDB) Check(ctx context.Context) (err error) {
 	// Ping the primary
 	err = c.Ping(ctx, readpref.Primary())
 	if err != nil {
+		if isAuthenticationError(err) {
+			return checker.NewAuthenticationError("failed to authenticate to the MongoDB server", err)
+		}
+
+		if checker.IsTLSVerificationError(err) {
+			return checker.NewPermanentError(checker.NewExpectedError("failed to verify the certificate of the MongoDB server", err))
+		}
+
 		if checker.IsConnectionRefused(err) || errors.Is(err, topology.ErrServerSelectionTimeout) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the MongoDB server", err,
@@ -82,3 +92,15 @@ func (m *MongoDB) Check(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// isAuthenticationError reports whether the server rejected the credentials
+func isAuthenticationError(err error) bool {
+	var authErr *auth.Error
+	if errors.As(err, &authErr) {
+		return true
+	}
+
+	// AuthenticationFailed
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == 18
+}
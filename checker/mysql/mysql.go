@@ -17,6 +17,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/go-sql-driver/mysql"
 	"wait4x.dev/v3/checker"
@@ -55,7 +56,8 @@ func (m *MySQL) Identity() (string, error) {
 func (m *MySQL) Check(ctx context.Context) (err error) {
 	db, err := sql.Open("mysql", m.dsn)
 	if err != nil {
-		return err
+		// The DSN is malformed
+		return checker.NewPermanentError(err)
 	}
 
 	defer func(db *sql.DB) {
@@ -66,6 +68,18 @@ func (m *MySQL) Check(ctx context.Context) (err error) {
 
 	err = db.PingContext(ctx)
 	if err != nil {
+		if isAuthenticationError(err) {
+			return checker.NewAuthenticationError("failed to authenticate to the mysql server", err)
+		}
+
+		if isUnknownDatabaseError(err) {
+			return checker.NewPermanentError(checker.NewExpectedError("the database doesn't exist", err))
+		}
+
+		if checker.IsTLSVerificationError(err) {
+			return checker.NewPermanentError(checker.NewExpectedError("failed to verify the certificate of the mysql server", err))
+		}
+
 		if checker.IsConnectionRefused(err) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the mysql server", err,
@@ -78,3 +92,22 @@ func (m *MySQL) Check(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// isAuthenticationError reports whether the server denied the access of the user
+func isAuthenticationError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+
+	// ER_ACCESS_DENIED_ERROR, ER_ACCESS_DENIED_NO_PASSWORD_ERROR
+	return mysqlErr.Number == 1045 || mysqlErr.Number == 1698
+}
+
+// isUnknownDatabaseError reports whether the database of the DSN doesn't exist
+func isUnknownDatabaseError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	// ER_BAD_DB_ERROR
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1049
+}
@@ -17,11 +17,12 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/url"
 	"wait4x.dev/v3/checker"
-	// Needed for the PostgreSQL driver
-	_ "github.com/lib/pq"
+
+	"github.com/lib/pq"
 	"regexp"
 )
 
@@ -53,11 +54,14 @@ func (p *PostgreSQL) Identity() (string, error) {
 
 // Check checks PostgreSQL connection
 func (p *PostgreSQL) Check(ctx context.Context) (err error) {
-	db, err := sql.Open("postgres", p.dsn)
+	// The connector parses the DSN without connecting to the server
+	connector, err := pq.NewConnector(p.dsn)
 	if err != nil {
-		return err
+		return checker.NewPermanentError(err)
 	}
 
+	db := sql.OpenDB(connector)
+
 	defer func(db *sql.DB) {
 		if dberr := db.Close(); dberr != nil {
 			err = dberr
@@ -66,6 +70,18 @@ func (p *PostgreSQL) Check(ctx context.Context) (err error) {
 
 	err = db.PingContext(ctx)
 	if err != nil {
+		if isAuthenticationError(err) {
+			return checker.NewAuthenticationError("failed to authenticate to the postgresql server", err)
+		}
+
+		if isUnknownDatabaseError(err) {
+			return checker.NewPermanentError(checker.NewExpectedError("the database doesn't exist", err))
+		}
+
+		if checker.IsTLSVerificationError(err) || errors.Is(err, pq.ErrSSLNotSupported) {
+			return checker.NewPermanentError(checker.NewExpectedError("failed to establish a tls connection to the postgresql server", err))
+		}
+
 		if checker.IsConnectionRefused(err) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the postgresql server", err,
@@ -78,3 +94,22 @@ func (p *PostgreSQL) Check(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// isAuthenticationError reports whether the server rejected the credentials
+func isAuthenticationError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	// invalid_password, invalid_authorization_specification
+	return pqErr.Code == "28P01" || pqErr.Code == "28000"
+}
+
+// isUnknownDatabaseError reports whether the database of the DSN doesn't exist
+func isUnknownDatabaseError(err error) bool {
+	var pqErr *pq.Error
+
+	// invalid_catalog_name
+	return errors.As(err, &pqErr) && pqErr.Code == "3D000"
+}
@@ -18,6 +18,7 @@ package rabbitmq
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"net"
@@ -119,6 +120,15 @@ func (r *RabbitMQ) Check(ctx context.Context) (err error) {
 	)
 
 	if err != nil {
+		var amqpErr *amqp.Error
+		if errors.As(err, &amqpErr) && amqpErr.Code == amqp.AccessRefused {
+			return checker.NewAuthenticationError("failed to authenticate to the rabbitmq server", err)
+		}
+
+		if checker.IsTLSVerificationError(err) {
+			return checker.NewPermanentError(checker.NewExpectedError("failed to verify the certificate of the rabbitmq server", err))
+		}
+
 		if checker.IsConnectionRefused(err) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the rabbitmq server", err,
@@ -87,7 +87,8 @@ func (r *Redis) Identity() (string, error) {
 func (r *Redis) Check(ctx context.Context) error {
 	opts, err := redis.ParseURL(r.address)
 	if err != nil {
-		return err
+		// The address is malformed
+		return checker.NewPermanentError(err)
 	}
 	opts.DialTimeout = r.timeout
 
@@ -96,6 +97,18 @@ func (r *Redis) Check(ctx context.Context) error {
 	// Check Redis connection
 	_, err = client.Ping(ctx).Result()
 	if err != nil {
+		if isAuthenticationError(err) {
+			return checker.NewAuthenticationError("failed to authenticate to the redis server", err)
+		}
+
+		if isUnknownDatabaseError(err) {
+			return checker.NewPermanentError(checker.NewExpectedError("the database doesn't exist", err, "db", opts.DB))
+		}
+
+		if checker.IsTLSVerificationError(err) {
+			return checker.NewPermanentError(checker.NewExpectedError("failed to verify the certificate of the redis server", err))
+		}
+
 		if checker.IsConnectionRefused(err) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the redis server", err,
@@ -141,3 +154,22 @@ func (r *Redis) Check(ctx context.Context) error {
 		"key", splittedKey[0], "actual", val, "expect", splittedKey[1],
 	)
 }
+
+// isAuthenticationError reports whether the server rejected the credentials, or requires them
+func isAuthenticationError(err error) bool {
+	var redisErr redis.Error
+	if !errors.As(err, &redisErr) {
+		return false
+	}
+
+	msg := redisErr.Error()
+
+	return strings.HasPrefix(msg, "WRONGPASS") || strings.HasPrefix(msg, "NOAUTH") || strings.HasPrefix(msg, "ERR invalid password")
+}
+
+// isUnknownDatabaseError reports whether the database number of the address is out of range
+func isUnknownDatabaseError(err error) bool {
+	var redisErr redis.Error
+
+	return errors.As(err, &redisErr) && strings.HasPrefix(redisErr.Error(), "ERR DB index is out of range")
+}
@@ -22,9 +22,11 @@ import (
 	"go.temporal.io/api/taskqueue/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	"net"
 	"os"
 	"regexp"
@@ -197,6 +199,10 @@ func (t *Temporal) getGRPCConn(ctx context.Context) (*grpc.ClientConn, error) {
 
 	conn, err := grpc.DialContext(ctx, t.target, opts...)
 	if err != nil {
+		if checker.IsTLSVerificationError(err) {
+			return nil, checker.NewPermanentError(checker.NewExpectedError("failed to verify the certificate of the grpc server", err))
+		}
+
 		if os.IsTimeout(err) {
 			return nil, checker.NewExpectedError("timed out while making a grpc call", err)
 		} else if checker.IsConnectionRefused(err) {
@@ -217,6 +223,10 @@ func (t *Temporal) checkServer(ctx context.Context, conn grpc.ClientConnInterfac
 
 	resp, err := healthClient.Check(ctx, req)
 	if err != nil {
+		if isAuthenticationError(err) {
+			return checker.NewAuthenticationError("failed to authenticate to the temporal server", err)
+		}
+
 		return checker.NewExpectedError("failed to health check", err)
 	}
 
@@ -243,6 +253,10 @@ func (t *Temporal) checkWorker(ctx context.Context, conn grpc.ClientConnInterfac
 
 	resp, err := client.DescribeTaskQueue(ctx, req)
 	if err != nil {
+		if isAuthenticationError(err) {
+			return checker.NewAuthenticationError("failed to authenticate to the temporal server", err)
+		}
+
 		return checker.NewExpectedError(
 			"failed to describe the task queue",
 			err,
@@ -277,3 +291,10 @@ func (t *Temporal) checkWorker(ctx context.Context, conn grpc.ClientConnInterfac
 
 	return nil
 }
+
+// isAuthenticationError reports whether the server rejected the credentials of the grpc call
+func isAuthenticationError(err error) bool {
+	code := status.Code(err)
+
+	return code == codes.Unauthenticated || code == codes.PermissionDenied
+}
@@ -15,6 +15,9 @@
 package checker
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"net"
 	"net/url"
 	"syscall"
@@ -36,3 +39,16 @@ func IsConnectionRefused(err error) bool {
 
 	return false
 }
+
+// IsTLSVerificationError attempts to determine if the given error was caused by a failed verification of the server certificate.
+func IsTLSVerificationError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+
+	return errors.As(err, &verificationErr) ||
+		errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
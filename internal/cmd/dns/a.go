@@ -17,12 +17,11 @@ package dns
 import (
 	"errors"
 	"fmt"
-	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/cmdutil"
 
-	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	dns "wait4x.dev/v3/checker/dns/a"
-	"wait4x.dev/v3/waiter"
 )
 
 // NewACommand creates the DNS A command
@@ -77,9 +76,9 @@ func runA(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse --expect-ip flag: %w", err)
 	}
 
-	logger, err := logr.FromContext(cmd.Context())
+	opts, err := cmdutil.WaiterOptions(cmd.Context())
 	if err != nil {
-		return fmt.Errorf("failed to get logger from context: %w", err)
+		return err
 	}
 
 	dc := dns.New(
@@ -88,11 +87,8 @@ func runA(cmd *cobra.Command, args []string) error {
 		dns.WithNameServer(nameserver),
 	)
 
-	return waiter.WaitContext(cmd.Context(),
-		dc,
-		waiter.WithTimeout(contextutil.GetTimeout(cmd.Context())),
-		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
-		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
-		waiter.WithLogger(logger),
+	return cmdutil.WaitContext(cmd.Context(),
+		[]checker.Checker{dc},
+		opts...,
 	)
 }
@@ -17,11 +17,10 @@ package dns
 import (
 	"errors"
 	"fmt"
-	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
+	"wait4x.dev/v3/checker"
 	dns "wait4x.dev/v3/checker/dns/cname"
-	"wait4x.dev/v3/internal/contextutil"
-	"wait4x.dev/v3/waiter"
+	"wait4x.dev/v3/internal/cmdutil"
 )
 
 // NewCNAMECommand creates a new Cobra command for the "dns CNAME" subcommand. This command
@@ -75,9 +74,9 @@ func runCNAME(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse --expect-domain flag: %w", err)
 	}
 
-	logger, err := logr.FromContext(cmd.Context())
+	opts, err := cmdutil.WaiterOptions(cmd.Context())
 	if err != nil {
-		return fmt.Errorf("failed to get logger from context: %w", err)
+		return err
 	}
 
 	dc := dns.New(
@@ -86,11 +85,8 @@ func runCNAME(cmd *cobra.Command, args []string) error {
 		dns.WithNameServer(nameserver),
 	)
 
-	return waiter.WaitContext(cmd.Context(),
-		dc,
-		waiter.WithTimeout(contextutil.GetTimeout(cmd.Context())),
-		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
-		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
-		waiter.WithLogger(logger),
+	return cmdutil.WaitContext(cmd.Context(),
+		[]checker.Checker{dc},
+		opts...,
 	)
 }
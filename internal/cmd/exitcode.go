@@ -0,0 +1,142 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/cmdutil"
+)
+
+const (
+	// ExitError is the exit code used when the command encounters an error.
+	ExitError = 1
+
+	// ExitUsage is the exit code used when the flags, the arguments or the options are invalid,
+	// e.g. the command failed before any check started or because of a malformed DSN.
+	ExitUsage = 2
+
+	// ExitConnectionRefused is the exit code used when a watched target became unhealthy because it
+	// couldn't be reached. A refused connection is retried until the time runs out otherwise, so
+	// waiting for an unreachable target exits with ExitTimedOut.
+	ExitConnectionRefused = 3
+
+	// ExitExpectationFailed is the exit code used when a target was reached but can't meet the expectation,
+	// e.g. the database doesn't exist, or when a watched target became unhealthy because of it.
+	ExitExpectationFailed = 4
+
+	// ExitAuthenticationFailed is the exit code used when a target rejected the credentials.
+	ExitAuthenticationFailed = 5
+
+	// ExitCommandFailed is the exit code used when the command after -- failed.
+	ExitCommandFailed = 6
+
+	// ExitTimedOut is the exit code used when the command times out, whatever the last check errors were.
+	ExitTimedOut = 124
+
+	// ExitInterrupted is the exit code used when the command is interrupted by a signal.
+	ExitInterrupted = 130
+)
+
+// CommandError is returned when the command after -- failed.
+type CommandError struct {
+	// Err is the error of running the command.
+	Err error
+}
+
+func (ce *CommandError) Unwrap() error {
+	return ce.Err
+}
+
+func (ce *CommandError) Error() string {
+	return ce.Err.Error()
+}
+
+// ExitCode returns the exit code of the error returned by the command.
+//
+// A timed out waiting always exits with ExitTimedOut. The other failed waitings, e.g. a permanent
+// error which stopped the retries or a watched target which became unhealthy, are mapped by the
+// errors of the checkers. When the checkers failed for different reasons, an authentication failure
+// takes precedence over a refused connection, which takes precedence over a failed expectation.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return ExitInterrupted
+	}
+
+	var commandError *CommandError
+	if errors.As(err, &commandError) {
+		return ExitCommandFailed
+	}
+
+	var waitError *cmdutil.WaitError
+	if !errors.As(err, &waitError) {
+		return ExitUsage
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ExitTimedOut
+	}
+
+	var authenticationError *checker.AuthenticationError
+	if errors.As(err, &authenticationError) {
+		return ExitAuthenticationFailed
+	}
+
+	if anyError(err, checker.IsConnectionRefused) {
+		return ExitConnectionRefused
+	}
+
+	var expectedError *checker.ExpectedError
+	if errors.As(err, &expectedError) {
+		return ExitExpectationFailed
+	}
+
+	// The remaining permanent errors are caused by the configuration, e.g. a malformed DSN
+	if checker.IsPermanentError(err) {
+		return ExitUsage
+	}
+
+	return ExitError
+}
+
+// anyError reports whether any error in the tree of the error matches the function.
+func anyError(err error, match func(error) bool) bool {
+	if err == nil {
+		return false
+	}
+
+	if match(err) {
+		return true
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return anyError(e.Unwrap(), match)
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if anyError(err, match) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/cmdutil"
+	"wait4x.dev/v3/internal/test"
+	"wait4x.dev/v3/waiter"
+)
+
+func TestExitCode(t *testing.T) {
+	refused := checker.NewExpectedError("failed to establish a tcp connection", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED})
+	expectation := checker.NewExpectedError("the status code doesn't expect", nil, "actual", 503)
+	authentication := checker.NewAuthenticationError("failed to authenticate to the mysql server", errors.New("access denied"))
+
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: nil, expected: 0},
+		{err: errors.New("unknown flag: --foo"), expected: ExitUsage},
+		{err: &CommandError{Err: errors.New("exit status 3")}, expected: ExitCommandFailed},
+		{err: context.Canceled, expected: ExitInterrupted},
+		{err: &cmdutil.WaitError{Err: &waiter.TimeoutError{}}, expected: ExitTimedOut},
+		{err: &cmdutil.WaitError{Err: &waiter.TimeoutError{LastErr: refused}}, expected: ExitTimedOut},
+		{err: &cmdutil.WaitError{Err: &waiter.TimeoutError{LastErr: expectation}}, expected: ExitTimedOut},
+		{err: &cmdutil.WaitError{Err: &waiter.TimeoutError{LastErr: authentication}}, expected: ExitTimedOut},
+		{err: &cmdutil.WaitError{Err: &waiter.CheckerError{Err: &waiter.TimeoutError{LastErr: refused, Attempts: 3}}}, expected: ExitTimedOut},
+		{err: &cmdutil.WaitError{Err: errors.Join(&waiter.TimeoutError{LastErr: expectation}, checker.NewPermanentError(authentication))}, expected: ExitTimedOut},
+		{err: &cmdutil.WaitError{Err: errors.Join(checker.NewPermanentError(expectation), refused, authentication)}, expected: ExitAuthenticationFailed},
+		{err: &cmdutil.WaitError{Err: errors.Join(expectation, refused)}, expected: ExitConnectionRefused},
+		{err: &cmdutil.WaitError{Err: &waiter.TimeoutError{LastErr: errors.New("error")}}, expected: ExitTimedOut},
+		{err: &cmdutil.WaitError{Err: fmt.Errorf("the checker became unhealthy: %w", expectation)}, expected: ExitExpectationFailed},
+		{err: &cmdutil.WaitError{Err: errors.New("error")}, expected: ExitError},
+		{err: &cmdutil.WaitError{Err: checker.NewPermanentError(errors.New("invalid DSN"))}, expected: ExitUsage},
+		{err: &cmdutil.WaitError{Err: checker.NewPermanentError(expectation)}, expected: ExitExpectationFailed},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ExitCode(tt.err), "%v", tt.err)
+	}
+}
+
+func TestExitCodeOfCommand(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+	_, err = test.ExecuteCommand(rootCmd, "tcp", addr, "-t", "1s")
+	assert.Equal(t, ExitTimedOut, ExitCode(err))
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+	_, err = test.ExecuteCommand(rootCmd, "tcp", addr, "--interval", "invalid")
+	assert.Equal(t, ExitUsage, ExitCode(err))
+
+	// A malformed DSN is a usage error, though it's only found once the waiting started
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewRedisCommand())
+	_, err = test.ExecuteCommand(rootCmd, "redis", "foo://x", "-t", "1s")
+	assert.Equal(t, ExitUsage, ExitCode(err))
+}
+
+func TestExitCodeOfFailedCommand(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+	_, err = test.ExecuteCommand(rootCmd, "tcp", ln.Addr().String(), "--", "false")
+	assert.Equal(t, ExitCommandFailed, ExitCode(err))
+}
@@ -0,0 +1,78 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"wait4x.dev/v3/waiter"
+)
+
+// printFailureHistory prints the distinct failures of the checkers which timed out. The message of
+// the error already tells the last failure, so nothing is printed for a checker which kept failing
+// the same way.
+func printFailureHistory(w io.Writer, err error) {
+	walkTimeoutErrors(err, "", func(label string, te *waiter.TimeoutError) {
+		if len(te.History) < 2 {
+			return
+		}
+
+		if label == "" {
+			_, _ = fmt.Fprintln(w, "Failures:")
+		} else {
+			_, _ = fmt.Fprintf(w, "Failures of %s:\n", label)
+		}
+
+		for _, failure := range te.History {
+			_, _ = fmt.Fprintf(w, "  %dx %s\n", failure.Count, failure.Err)
+		}
+	})
+}
+
+// walkTimeoutErrors calls fn for each TimeoutError in the error tree with the label of the checker
+// it's attributed to.
+func walkTimeoutErrors(err error, label string, fn func(label string, te *waiter.TimeoutError)) {
+	switch e := err.(type) {
+	case nil:
+		return
+	case *waiter.TimeoutError:
+		fn(label, e)
+		return
+	case *waiter.CheckerError:
+		label = checkerLabel(e)
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		walkTimeoutErrors(e.Unwrap(), label, fn)
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			walkTimeoutErrors(err, label, fn)
+		}
+	}
+}
+
+// checkerLabel returns the name and the identity of the checker the way the CheckerError tells it.
+func checkerLabel(ce *waiter.CheckerError) string {
+	switch {
+	case ce.Name != "" && ce.Identity != "":
+		return fmt.Sprintf("%s (%s)", ce.Name, ce.Identity)
+	case ce.Name != "":
+		return ce.Name
+	default:
+		return ce.Identity
+	}
+}
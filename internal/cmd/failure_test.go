@@ -0,0 +1,49 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wait4x.dev/v3/internal/cmdutil"
+	"wait4x.dev/v3/waiter"
+)
+
+func TestPrintFailureHistory(t *testing.T) {
+	refused := errors.New("connection refused")
+	unavailable := errors.New("the status code doesn't expect, actual 503")
+
+	history := []waiter.Failure{{Err: refused, Count: 2}, {Err: unavailable, Count: 7}}
+
+	var b bytes.Buffer
+	printFailureHistory(&b, &cmdutil.WaitError{Err: &waiter.TimeoutError{LastErr: unavailable, Attempts: 9, History: history}})
+	assert.Equal(t, "Failures:\n  2x connection refused\n  7x the status code doesn't expect, actual 503\n", b.String())
+
+	b.Reset()
+	printFailureHistory(&b, &cmdutil.WaitError{Err: errors.Join(
+		&waiter.CheckerError{Name: "api", Identity: "127.0.0.1:8080", Err: &waiter.TimeoutError{LastErr: unavailable, Attempts: 9, History: history}},
+		&waiter.CheckerError{Identity: "127.0.0.1:5432", Err: &waiter.TimeoutError{LastErr: refused, Attempts: 9, History: history[:1]}},
+		&waiter.CheckerError{Identity: "127.0.0.1:6379", Err: &waiter.TimeoutError{LastErr: refused, Attempts: 3, History: history}},
+	)})
+	assert.Equal(t, "Failures of api (127.0.0.1:8080):\n  2x connection refused\n  7x the status code doesn't expect, actual 503\n"+
+		"Failures of 127.0.0.1:6379:\n  2x connection refused\n  7x the status code doesn't expect, actual 503\n", b.String())
+
+	b.Reset()
+	printFailureHistory(&b, errors.New("error"))
+	assert.Empty(t, b.String())
+}
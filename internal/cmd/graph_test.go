@@ -0,0 +1,91 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wait4x.dev/v3/internal/test"
+	"wait4x.dev/v3/waiter"
+)
+
+func TestGraphCommandInvalidArgument(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewGraphCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "graph")
+	assert.EqualError(t, err, "NAME=TARGET is required argument for the graph command")
+
+	_, err = test.ExecuteCommand(rootCmd, "graph", "tcp://127.0.0.1:8080")
+	assert.EqualError(t, err, `invalid target "tcp://127.0.0.1:8080", expected NAME=TARGET`)
+
+	_, err = test.ExecuteCommand(rootCmd, "graph", "db=ftp://127.0.0.1")
+	assert.EqualError(t, err, `invalid target "db": unsupported scheme "ftp"`)
+
+	_, err = test.ExecuteCommand(rootCmd, "graph", "db=tcp://127.0.0.1:8080", "--depends-on", "api=db")
+	assert.EqualError(t, err, `invalid --depends-on "api=db", unknown target api`)
+}
+
+func TestGraphCommandUnsupportedFlags(t *testing.T) {
+	for _, args := range [][]string{{"--any"}, {"--min-ready", "1"}} {
+		rootCmd := NewRootCommand()
+		rootCmd.AddCommand(NewGraphCommand())
+
+		_, err := test.ExecuteCommand(rootCmd, append([]string{"graph", "db=tcp://127.0.0.1:8080"}, args...)...)
+		assert.EqualError(t, err, "--any and --min-ready aren't supported by the graph command")
+	}
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewGraphCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "graph", "db=tcp://127.0.0.1:8080", "--name", "db")
+	assert.EqualError(t, err, "--name isn't supported by the graph command, the targets are named NAME=TARGET")
+}
+
+func TestGraphCommandCycle(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewGraphCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "graph", "a=tcp://127.0.0.1:8080", "b=tcp://127.0.0.1:8081", "--depends-on", "a=b", "--depends-on", "b=a")
+	assert.EqualError(t, err, "dependency cycle detected: a -> b -> a")
+}
+
+func TestGraphCommandSuccess(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewGraphCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "graph", "api=tcp://"+ln.Addr().String(), "db=tcp://"+ln.Addr().String(), "--depends-on", "api=db", "-t", "2s")
+	assert.Nil(t, err)
+}
+
+func TestGraphCommandDependencyFail(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewGraphCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "graph", "api=tcp://"+ln.Addr().String(), "db=tcp://127.0.0.1:8080", "--depends-on", "api=db", "-t", "1s")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, waiter.ErrDependencyNotReady)
+}
@@ -24,13 +24,11 @@ import (
 	"net/url"
 	"strings"
 
-	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/http"
-	"wait4x.dev/v3/internal/contextutil"
-	"wait4x.dev/v3/waiter"
+	"wait4x.dev/v3/internal/cmdutil"
 )
 
 // NewHTTPCommand creates the http sub-command
@@ -139,7 +137,7 @@ func runHTTP(cmd *cobra.Command, args []string) error {
 	certFile, _ := cmd.Flags().GetString("cert-file")
 	keyFile, _ := cmd.Flags().GetString("key-file")
 
-	logger, err := logr.FromContext(cmd.Context())
+	opts, err := cmdutil.WaiterOptions(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -195,17 +193,9 @@ func runHTTP(cmd *cobra.Command, args []string) error {
 		)
 	}
 
-	return waiter.WaitParallelContext(
+	return cmdutil.WaitContext(
 		cmd.Context(),
 		checkers,
-		waiter.WithTimeout(contextutil.GetTimeout(cmd.Context())),
-		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
-		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
-		waiter.WithBackoffPolicy(contextutil.GetBackoffPolicy(cmd.Context())),
-		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(cmd.Context())),
-		waiter.WithBackoffExponentialMaxInterval(
-			contextutil.GetBackoffExponentialMaxInterval(cmd.Context()),
-		),
-		waiter.WithLogger(logger),
+		opts...,
 	)
 }
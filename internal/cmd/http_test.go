@@ -68,7 +68,7 @@ func TestHTTPConnectionFail(t *testing.T) {
 
 	_, err := test.ExecuteCommand(rootCmd, "http", "http://not-exists-doomain.tld", "-t", "2s")
 
-	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
 }
 
 func TestHTTPRequestHeaderSuccess(t *testing.T) {
@@ -16,14 +16,11 @@ package cmd
 
 import (
 	"errors"
-	"fmt"
-	"github.com/go-logr/logr"
-	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/cmdutil"
 
 	"github.com/spf13/cobra"
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/mongodb"
-	"wait4x.dev/v3/waiter"
 )
 
 // NewMongoDBCommand creates the mongodb sub-command
@@ -52,9 +49,9 @@ func NewMongoDBCommand() *cobra.Command {
 }
 
 func runMongoDB(cmd *cobra.Command, args []string) error {
-	logger, err := logr.FromContext(cmd.Context())
+	opts, err := cmdutil.WaiterOptions(cmd.Context())
 	if err != nil {
-		return fmt.Errorf("unable to get logger from context: %w", err)
+		return err
 	}
 
 	// ArgsLenAtDash returns -1 when -- was not specified
@@ -67,15 +64,9 @@ func runMongoDB(cmd *cobra.Command, args []string) error {
 		checkers[i] = mongodb.New(arg)
 	}
 
-	return waiter.WaitParallelContext(
+	return cmdutil.WaitContext(
 		cmd.Context(),
 		checkers,
-		waiter.WithTimeout(contextutil.GetTimeout(cmd.Context())),
-		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
-		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
-		waiter.WithBackoffPolicy(contextutil.GetBackoffPolicy(cmd.Context())),
-		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(cmd.Context())),
-		waiter.WithBackoffExponentialMaxInterval(contextutil.GetBackoffExponentialMaxInterval(cmd.Context())),
-		waiter.WithLogger(logger),
+		opts...,
 	)
 }
@@ -17,13 +17,11 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"github.com/go-logr/logr"
-	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/cmdutil"
 
 	"github.com/spf13/cobra"
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/rabbitmq"
-	"wait4x.dev/v3/waiter"
 )
 
 // NewRabbitMQCommand creates the rabbitmq sub-command
@@ -65,9 +63,9 @@ func runRabbitMQ(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to parse --insecure-skip-tls-verify flag: %w", err)
 	}
 
-	logger, err := logr.FromContext(cmd.Context())
+	opts, err := cmdutil.WaiterOptions(cmd.Context())
 	if err != nil {
-		return fmt.Errorf("unable to get logger from context: %w", err)
+		return err
 	}
 
 	// ArgsLenAtDash returns -1 when -- was not specified
@@ -84,15 +82,9 @@ func runRabbitMQ(cmd *cobra.Command, args []string) error {
 		)
 	}
 
-	return waiter.WaitParallelContext(
+	return cmdutil.WaitContext(
 		cmd.Context(),
 		checkers,
-		waiter.WithTimeout(contextutil.GetTimeout(cmd.Context())),
-		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
-		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
-		waiter.WithBackoffPolicy(contextutil.GetBackoffPolicy(cmd.Context())),
-		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(cmd.Context())),
-		waiter.WithBackoffExponentialMaxInterval(contextutil.GetBackoffExponentialMaxInterval(cmd.Context())),
-		waiter.WithLogger(logger),
+		opts...,
 	)
 }
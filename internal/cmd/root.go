@@ -16,7 +16,6 @@ package cmd
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -25,6 +24,9 @@ import (
 	"wait4x.dev/v3/internal/cmd/dns"
 	"wait4x.dev/v3/internal/cmd/temporal"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/output"
+	"wait4x.dev/v3/internal/progress"
+	"wait4x.dev/v3/internal/report"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zerologr"
@@ -35,14 +37,6 @@ import (
 	"github.com/spf13/cobra"
 )
 
-const (
-	// ExitError is the exit code used when the command encounters an error.
-	ExitError = 1
-
-	// ExitTimedOut is the exit code used when the command times out.
-	ExitTimedOut = 124
-)
-
 // NewRootCommand creates the root command
 func NewRootCommand() *cobra.Command {
 	rootCmd := &cobra.Command{
@@ -63,16 +57,68 @@ func NewRootCommand() *cobra.Command {
 				return fmt.Errorf("unable to parse --no-color flag: %w", err)
 			}
 
+			showProgress, err := cmd.Flags().GetBool("progress")
+			if err != nil {
+				return fmt.Errorf("unable to parse --progress flag: %w", err)
+			}
+			// The progress view is only useful on terminals
+			showProgress = showProgress && !quiet && progress.IsTerminal(os.Stderr)
+
+			outputFormat, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return fmt.Errorf("unable to parse --output flag: %w", err)
+			}
+
+			reports, err := cmd.Flags().GetStringArray("report")
+			if err != nil {
+				return fmt.Errorf("unable to parse --report flag: %w", err)
+			}
+
+			metricsFile, err := cmd.Flags().GetString("metrics-file")
+			if err != nil {
+				return fmt.Errorf("unable to parse --metrics-file flag: %w", err)
+			}
+
+			metricsAddr, err := cmd.Flags().GetString("metrics-addr")
+			if err != nil {
+				return fmt.Errorf("unable to parse --metrics-addr flag: %w", err)
+			}
+
 			timeout, err := cmd.Flags().GetDuration("timeout")
 			if err != nil {
 				return fmt.Errorf("unable to parse --timeout flag: %w", err)
 			}
 
+			rawDeadline, err := cmd.Flags().GetString("deadline")
+			if err != nil {
+				return fmt.Errorf("unable to parse --deadline flag: %w", err)
+			}
+
+			deadline, err := parseDeadline(rawDeadline, time.Now())
+			if err != nil {
+				return fmt.Errorf("unable to parse --deadline flag: %w", err)
+			}
+
+			attemptTimeout, err := cmd.Flags().GetDuration("attempt-timeout")
+			if err != nil {
+				return fmt.Errorf("unable to parse --attempt-timeout flag: %w", err)
+			}
+
 			interval, err := cmd.Flags().GetDuration("interval")
 			if err != nil {
 				return fmt.Errorf("unable to parse --interval flag: %w", err)
 			}
 
+			initialDelay, err := cmd.Flags().GetDuration("initial-delay")
+			if err != nil {
+				return fmt.Errorf("unable to parse --initial-delay flag: %w", err)
+			}
+
+			gracePeriod, err := cmd.Flags().GetDuration("grace-period")
+			if err != nil {
+				return fmt.Errorf("unable to parse --grace-period flag: %w", err)
+			}
+
 			invertCheck, err := cmd.Flags().GetBool("invert-check")
 			if err != nil {
 				return fmt.Errorf("unable to parse --invert-check flag: %w", err)
@@ -83,6 +129,11 @@ func NewRootCommand() *cobra.Command {
 				return fmt.Errorf("unable to parse --backoff-policy flag: %w", err)
 			}
 
+			backoffJitter, err := cmd.Flags().GetString("backoff-jitter")
+			if err != nil {
+				return fmt.Errorf("unable to parse --backoff-jitter flag: %w", err)
+			}
+
 			backoffCoefficient, err := cmd.Flags().GetFloat64("backoff-exponential-coefficient")
 			if err != nil {
 				return fmt.Errorf("unable to parse --backoff-exponential-coefficient flag: %w", err)
@@ -93,28 +144,150 @@ func NewRootCommand() *cobra.Command {
 				return fmt.Errorf("unable to parse --backoff-exponential-max-interval flag: %w", err)
 			}
 
+			successThreshold, err := cmd.Flags().GetInt("success-threshold")
+			if err != nil {
+				return fmt.Errorf("unable to parse --success-threshold flag: %w", err)
+			}
+
+			anyReady, err := cmd.Flags().GetBool("any")
+			if err != nil {
+				return fmt.Errorf("unable to parse --any flag: %w", err)
+			}
+
+			minReady, err := cmd.Flags().GetInt("min-ready")
+			if err != nil {
+				return fmt.Errorf("unable to parse --min-ready flag: %w", err)
+			}
+
+			maxConcurrency, err := cmd.Flags().GetInt("max-concurrency")
+			if err != nil {
+				return fmt.Errorf("unable to parse --max-concurrency flag: %w", err)
+			}
+
+			watch, err := cmd.Flags().GetBool("watch")
+			if err != nil {
+				return fmt.Errorf("unable to parse --watch flag: %w", err)
+			}
+
+			failureThreshold, err := cmd.Flags().GetInt("failure-threshold")
+			if err != nil {
+				return fmt.Errorf("unable to parse --failure-threshold flag: %w", err)
+			}
+
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return fmt.Errorf("unable to parse --name flag: %w", err)
+			}
+
+			cmd.SetContext(contextutil.WithProgress(cmd.Context(), showProgress))
+			cmd.SetContext(contextutil.WithNoColor(cmd.Context(), color.NoColor || noColor))
+			cmd.SetContext(contextutil.WithOutput(cmd.Context(), outputFormat))
+			cmd.SetContext(contextutil.WithReports(cmd.Context(), reports))
+			cmd.SetContext(contextutil.WithMetricsFile(cmd.Context(), metricsFile))
+			cmd.SetContext(contextutil.WithMetricsAddr(cmd.Context(), metricsAddr))
 			cmd.SetContext(contextutil.WithTimeout(cmd.Context(), timeout))
+			cmd.SetContext(contextutil.WithDeadline(cmd.Context(), deadline))
+			cmd.SetContext(contextutil.WithAttemptTimeout(cmd.Context(), attemptTimeout))
 			cmd.SetContext(contextutil.WithInterval(cmd.Context(), interval))
+			cmd.SetContext(contextutil.WithInitialDelay(cmd.Context(), initialDelay))
+			cmd.SetContext(contextutil.WithGracePeriod(cmd.Context(), gracePeriod))
 			cmd.SetContext(contextutil.WithInvertCheck(cmd.Context(), invertCheck))
 			cmd.SetContext(contextutil.WithBackoffPolicy(cmd.Context(), backoffPolicy))
+			cmd.SetContext(contextutil.WithBackoffJitter(cmd.Context(), backoffJitter))
 			cmd.SetContext(contextutil.WithBackoffCoefficient(cmd.Context(), backoffCoefficient))
 			cmd.SetContext(contextutil.WithBackoffExponentialMaxInterval(cmd.Context(), backoffExpMaxInterval))
+			cmd.SetContext(contextutil.WithSuccessThreshold(cmd.Context(), successThreshold))
+			cmd.SetContext(contextutil.WithAny(cmd.Context(), anyReady))
+			cmd.SetContext(contextutil.WithMinReady(cmd.Context(), minReady))
+			cmd.SetContext(contextutil.WithMaxConcurrency(cmd.Context(), maxConcurrency))
+			cmd.SetContext(contextutil.WithWatch(cmd.Context(), watch))
+			cmd.SetContext(contextutil.WithFailureThreshold(cmd.Context(), failureThreshold))
+			cmd.SetContext(contextutil.WithName(cmd.Context(), name))
+
+			// Validate output format value
+			outputFormatValues := []string{output.FormatText, output.FormatJSON, output.FormatLogfmt}
+			if !contains(outputFormatValues, outputFormat) {
+				return fmt.Errorf("--output must be one of %v", outputFormatValues)
+			}
+
+			for _, r := range reports {
+				if _, err := report.Parse(r); err != nil {
+					return fmt.Errorf("--report is invalid: %w", err)
+				}
+			}
 
 			// Validate backoff policy value
-			backoffPolicyValues := []string{waiter.BackoffPolicyExponential, waiter.BackoffPolicyLinear}
+			backoffPolicyValues := []string{
+				waiter.BackoffPolicyExponential,
+				waiter.BackoffPolicyLinear,
+				waiter.BackoffPolicyFibonacci,
+				waiter.BackoffPolicyDecorrelatedJitter,
+			}
 			if !contains(backoffPolicyValues, backoffPolicy) {
 				return fmt.Errorf("--backoff-policy must be one of %v", backoffPolicyValues)
 			}
 
-			if backoffPolicy == waiter.BackoffPolicyExponential && backoffExpMaxInterval < interval {
+			// Validate backoff jitter value
+			backoffJitterValues := []string{waiter.BackoffJitterNone, waiter.BackoffJitterFull, waiter.BackoffJitterEqual}
+			if !contains(backoffJitterValues, backoffJitter) {
+				return fmt.Errorf("--backoff-jitter must be one of %v", backoffJitterValues)
+			}
+
+			if backoffPolicy == waiter.BackoffPolicyDecorrelatedJitter && backoffJitter != waiter.BackoffJitterNone {
+				return fmt.Errorf("--backoff-jitter can't be used with the %s backoff policy", backoffPolicy)
+			}
+
+			if backoffPolicy != waiter.BackoffPolicyLinear && backoffExpMaxInterval < interval {
 				return fmt.Errorf("--backoff-exponential-max-interval must be greater than --interval")
 			}
 
+			if attemptTimeout < 0 {
+				return fmt.Errorf("--attempt-timeout must be greater than or equal to 0")
+			}
+
+			if initialDelay < 0 {
+				return fmt.Errorf("--initial-delay must be greater than or equal to 0")
+			}
+
+			if gracePeriod < 0 {
+				return fmt.Errorf("--grace-period must be greater than or equal to 0")
+			}
+
+			if successThreshold < 1 {
+				return fmt.Errorf("--success-threshold must be greater than 0")
+			}
+
+			if minReady < 0 {
+				return fmt.Errorf("--min-ready must be greater than or equal to 0")
+			}
+
+			if maxConcurrency < 0 {
+				return fmt.Errorf("--max-concurrency must be greater than or equal to 0")
+			}
+
+			if anyReady && minReady != 0 {
+				return fmt.Errorf("--any and --min-ready can't be used together")
+			}
+
+			if failureThreshold < 1 {
+				return fmt.Errorf("--failure-threshold must be greater than 0")
+			}
+
+			if watch && (anyReady || minReady != 0) {
+				return fmt.Errorf("--watch can't be used with --any or --min-ready")
+			}
+
+			// Watching only stops with an error, so the command would never run
+			if watch && cmd.ArgsLenAtDash() != -1 && len(args) > cmd.ArgsLenAtDash() {
+				return fmt.Errorf("--watch can't be used with a command to execute")
+			}
+
 			// Prevent showing error when the quiet mode enabled.
 			cmd.SilenceErrors = quiet
 
 			lvl := zerolog.InfoLevel
-			if quiet {
+			// The progress view and the event stream replace the logs
+			if quiet || showProgress || outputFormat != output.FormatText {
 				lvl = zerolog.Disabled
 			}
 
@@ -150,7 +323,9 @@ func NewRootCommand() *cobra.Command {
 				c.Stdout = os.Stdout
 				c.Stderr = os.Stderr
 
-				return c.Run()
+				if err := c.Run(); err != nil {
+					return &CommandError{Err: err}
+				}
 			}
 
 			return nil
@@ -158,14 +333,31 @@ func NewRootCommand() *cobra.Command {
 	}
 
 	rootCmd.PersistentFlags().DurationP("interval", "i", 1*time.Second, "Interval time between each loop.")
-	rootCmd.PersistentFlags().String("backoff-policy", "linear", `Select the backoff policy ("`+waiter.BackoffPolicyLinear+`"|"`+waiter.BackoffPolicyExponential+`".`)
-	rootCmd.PersistentFlags().Duration("backoff-exponential-max-interval", 5*time.Second, "Maximum interval time between each loop when backoff-policy is exponential.")
+	rootCmd.PersistentFlags().String("backoff-policy", "linear", `Select the backoff policy ("`+waiter.BackoffPolicyLinear+`"|"`+waiter.BackoffPolicyExponential+`"|"`+waiter.BackoffPolicyFibonacci+`"|"`+waiter.BackoffPolicyDecorrelatedJitter+`").`)
+	rootCmd.PersistentFlags().String("backoff-jitter", waiter.BackoffJitterNone, `Select the jitter applied on top of the backoff policy ("`+waiter.BackoffJitterNone+`"|"`+waiter.BackoffJitterFull+`"|"`+waiter.BackoffJitterEqual+`").`)
+	rootCmd.PersistentFlags().Duration("backoff-exponential-max-interval", 5*time.Second, "Maximum interval time between each loop when backoff-policy isn't linear.")
 	rootCmd.PersistentFlags().Float64("backoff-exponential-coefficient", 2.0, "Coefficient used to calculate the exponential backoff when backoff-policy is exponential.")
 	rootCmd.PersistentFlags().DurationP("timeout", "t", 10*time.Second, "Timeout is the maximum amount of time that Wait4X will wait for a checking operation, 0 is unlimited.")
+	rootCmd.PersistentFlags().String("deadline", "", "Absolute time at which Wait4X stops waiting, as an RFC3339 time or a wall-clock time of today like 15:04 in the local time zone. The earliest of the timeout and the deadline applies.")
+	rootCmd.PersistentFlags().Duration("attempt-timeout", 0, "Attempt timeout is the maximum amount of time that Wait4X will wait for each of checking attempts, 0 is unlimited.")
+	rootCmd.PersistentFlags().Duration("initial-delay", 0, "Delay before the first check, it counts towards the timeout.")
+	rootCmd.PersistentFlags().Duration("grace-period", 0, "Warm-up period starting with the first check, during which failed checks are retried without being logged as errors.")
 	rootCmd.PersistentFlags().BoolP("invert-check", "v", false, "Invert the sense of checking.")
+	rootCmd.PersistentFlags().Int("success-threshold", 1, "Number of consecutive successful checks required before the target is considered ready.")
 	rootCmd.PersistentFlags().StringP("log-level", "l", zerolog.InfoLevel.String(), "Set the logging level (\"trace\"|\"debug\"|\"info\")")
 	rootCmd.PersistentFlags().MarkDeprecated("log-level", "You don't need to the flag anymore. By default, Wait4X returns error logs. This flag will be removed in v4.0.0")
+	rootCmd.PersistentFlags().Bool("any", false, "Wait until any of the given addresses is ready instead of all of them.")
+	rootCmd.PersistentFlags().Int("min-ready", 0, "Wait until at least the given number of addresses are ready, 0 means all of them.")
+	rootCmd.PersistentFlags().Int("max-concurrency", 0, "Maximum number of checks running at the same time when checking multiple addresses, 0 is unlimited.")
+	rootCmd.PersistentFlags().Bool("watch", false, "Keep checking the addresses after they are ready and exit with an error once one of them becomes unhealthy.")
+	rootCmd.PersistentFlags().Int("failure-threshold", 1, "Number of consecutive failed checks required before a watched address is considered unhealthy.")
+	rootCmd.PersistentFlags().String("name", "", "Human-friendly name of the checked addresses used in logs and errors instead of the checker type.")
 	rootCmd.PersistentFlags().Bool("no-color", false, "If specified, output won't contain any color.")
+	rootCmd.PersistentFlags().StringP("output", "o", output.FormatText, `Output format ("`+output.FormatText+`"|"`+output.FormatJSON+`"|"`+output.FormatLogfmt+`"), the json and logfmt formats write an event per line to stdout instead of the logs.`)
+	rootCmd.PersistentFlags().StringArray("report", nil, `Write a report when the command exits, "junit=PATH" for a JUnit XML file or "summary" for a table on stderr. It can be used multiple times.`)
+	rootCmd.PersistentFlags().String("metrics-file", "", "Write the metrics in the Prometheus text format to the file when the command exits, e.g. for the textfile collector of the node exporter.")
+	rootCmd.PersistentFlags().String("metrics-addr", "", "Serve the metrics in the Prometheus text format on the address, e.g. :9090, at /metrics while the command runs.")
+	rootCmd.PersistentFlags().Bool("progress", false, "Show a live progress view instead of the logs, disabled when stderr isn't a terminal.")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Quiet or silent mode. Do not show logs or error messages.")
 
 	return rootCmd
@@ -184,16 +376,22 @@ func Execute() {
 	rootCmd.AddCommand(NewMongoDBCommand())
 	rootCmd.AddCommand(NewRabbitMQCommand())
 	rootCmd.AddCommand(temporal.NewTemporalCommand())
+	rootCmd.AddCommand(NewGraphCommand())
 	rootCmd.AddCommand(NewVersionCommand())
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 
-	if err := rootCmd.ExecuteContext(ctx); err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			os.Exit(ExitTimedOut)
+	if c, err := rootCmd.ExecuteContextC(ctx); err != nil {
+		if !c.SilenceErrors {
+			printFailureHistory(c.ErrOrStderr(), err)
+		}
+
+		// The context is only canceled by the signal, the command after -- may fail because of it as well
+		if ctx.Err() != nil {
+			os.Exit(ExitInterrupted)
 		}
 
-		os.Exit(ExitError)
+		os.Exit(ExitCode(err))
 	}
 }
@@ -16,9 +16,13 @@ package cmd
 
 import (
 	"context"
+	"net"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 	"wait4x.dev/v3/internal/test"
+	"wait4x.dev/v3/waiter"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -51,5 +55,169 @@ func TestTcpConnectionFail(t *testing.T) {
 
 	_, err := test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", "-t", "2s")
 
-	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTcpConnectionAnySuccess(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", ln.Addr().String(), "--any", "-t", "2s")
+
+	assert.Nil(t, err)
+}
+
+func TestTcpConnectionMinReady(t *testing.T) {
+	first, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer first.Close()
+
+	second, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer second.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "tcp", first.Addr().String(), "127.0.0.1:8080", second.Addr().String(), "--min-ready", "2", "-t", "2s")
+
+	assert.Nil(t, err)
+}
+
+func TestTcpConnectionInvalidMinReady(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", "--min-ready", "2")
+
+	assert.EqualError(t, err, "--min-ready must be less than or equal to the number of addresses (1)")
+}
+
+func TestTcpConnectionWatch(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	// Take the listener down after the first checks
+	time.AfterFunc(300*time.Millisecond, func() { ln.Close() })
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "tcp", ln.Addr().String(), "--watch", "--failure-threshold", "2", "-i", "100ms")
+
+	assert.ErrorIs(t, err, waiter.ErrUnhealthy)
+}
+
+func TestTcpConnectionWatchWithAny(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", "--watch", "--any")
+
+	assert.EqualError(t, err, "--watch can't be used with --any or --min-ready")
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", "--watch", "--min-ready", "1")
+
+	assert.EqualError(t, err, "--watch can't be used with --any or --min-ready")
+}
+
+func TestTcpConnectionWatchWithCommand(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", "--watch", "--", "echo", "ready")
+
+	assert.EqualError(t, err, "--watch can't be used with a command to execute")
+}
+
+func TestTcpConnectionDeadline(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	deadline := time.Now().Add(500 * time.Millisecond).Format(time.RFC3339Nano)
+	_, err := test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", "--deadline", deadline)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTcpConnectionInvalidDeadline(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", "--deadline", "tomorrow")
+
+	assert.EqualError(t, err, `unable to parse --deadline flag: invalid deadline "tomorrow", expected an RFC3339 time or a wall-clock time like 15:04 or 15:04:05`)
+}
+
+func TestTcpConnectionJUnitReport(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	path := filepath.Join(t.TempDir(), "report.xml")
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "tcp", ln.Addr().String(), "127.0.0.1:8080", "-t", "1s", "--report", "junit="+path)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	report, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(report), `<testsuite name="wait4x" tests="2" failures="1"`)
+	assert.Contains(t, string(report), `<testcase name="`+ln.Addr().String()+`" classname="TCP"`)
+}
+
+func TestTcpConnectionAnyJUnitReport(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	path := filepath.Join(t.TempDir(), "report.xml")
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", ln.Addr().String(), "--any", "-t", "2s", "--report", "junit="+path)
+	assert.NoError(t, err)
+
+	// The checker canceled by the --any mode isn't a failure
+	report, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(report), `<testsuite name="wait4x" tests="2" failures="0" skipped="1"`)
+	assert.Contains(t, string(report), `<skipped message="canceled"></skipped>`)
+}
+
+func TestTcpConnectionInvalidReport(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "tcp", "127.0.0.1:8080", "--report", "junit")
+
+	assert.EqualError(t, err, "--report is invalid: the junit report requires a path, e.g. junit=report.xml")
+}
+
+func TestTcpConnectionMetricsFile(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	path := filepath.Join(t.TempDir(), "wait4x.prom")
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewTCPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "tcp", ln.Addr().String(), "--metrics-file", path)
+	assert.NoError(t, err)
+
+	metrics, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(metrics), `wait4x_ready{checker="TCP",identity="`+ln.Addr().String()+`",index="0"} 1`)
 }
@@ -17,11 +17,10 @@ package temporal
 import (
 	"errors"
 	"fmt"
-	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
+	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/temporal"
-	"wait4x.dev/v3/internal/contextutil"
-	"wait4x.dev/v3/waiter"
+	"wait4x.dev/v3/internal/cmdutil"
 )
 
 // NewWorkerCommand creates the worker sub-command
@@ -86,9 +85,9 @@ func runWorker(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse --expect-worker-identity-regex flag: %w", err)
 	}
 
-	logger, err := logr.FromContext(cmd.Context())
+	opts, err := cmdutil.WaiterOptions(cmd.Context())
 	if err != nil {
-		return fmt.Errorf("failed to get logger from context: %w", err)
+		return err
 	}
 
 	// ArgsLenAtDash returns -1 when -- was not specified
@@ -107,12 +106,9 @@ func runWorker(cmd *cobra.Command, args []string) error {
 		temporal.WithExpectWorkerIdentityRegex(expectWorkerIdentityRegex),
 	)
 
-	return waiter.WaitContext(
+	return cmdutil.WaitContext(
 		cmd.Context(),
-		tc,
-		waiter.WithTimeout(contextutil.GetTimeout(cmd.Context())),
-		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
-		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
-		waiter.WithLogger(logger),
+		[]checker.Checker{tc},
+		opts...,
 	)
 }
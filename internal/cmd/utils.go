@@ -14,6 +14,11 @@
 
 package cmd
 
+import (
+	"fmt"
+	"time"
+)
+
 func contains(s []string, str string) bool {
 	for _, v := range s {
 		if v == str {
@@ -23,3 +28,27 @@ func contains(s []string, str string) bool {
 
 	return false
 }
+
+// parseDeadline parses an RFC3339 time or a wall-clock time of the day of now, the empty value is no deadline.
+func parseDeadline(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if deadline, err := time.Parse(time.RFC3339, value); err == nil {
+		return deadline, nil
+	}
+
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		clock, err := time.ParseInLocation(layout, value, now.Location())
+		if err != nil {
+			continue
+		}
+
+		year, month, day := now.Date()
+
+		return time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), 0, now.Location()), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid deadline %q, expected an RFC3339 time or a wall-clock time like 15:04 or 15:04:05", value)
+}
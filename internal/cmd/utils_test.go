@@ -0,0 +1,45 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDeadline(t *testing.T) {
+	now := time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{value: "", expected: time.Time{}},
+		{value: "2025-03-14T14:05:00Z", expected: time.Date(2025, 3, 14, 14, 5, 0, 0, time.UTC)},
+		{value: "14:05", expected: time.Date(2025, 3, 14, 14, 5, 0, 0, time.UTC)},
+		{value: "14:05:30", expected: time.Date(2025, 3, 14, 14, 5, 30, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		deadline, err := parseDeadline(tt.value, now)
+		assert.NoError(t, err)
+		assert.True(t, tt.expected.Equal(deadline), "%s: expected %s, got %s", tt.value, tt.expected, deadline)
+	}
+
+	_, err := parseDeadline("25:00", now)
+	assert.Error(t, err)
+}
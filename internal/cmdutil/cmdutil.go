@@ -0,0 +1,311 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cmdutil provides utilities shared by the commands.
+package cmdutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/metrics"
+	"wait4x.dev/v3/internal/output"
+	"wait4x.dev/v3/internal/progress"
+	"wait4x.dev/v3/internal/report"
+	"wait4x.dev/v3/internal/tracing"
+	"wait4x.dev/v3/waiter"
+)
+
+// WaitError is returned by WaitContext and WaitGraphContext when the waiting failed, as opposed to
+// the errors which occurred before any checker started, e.g. the invalid options.
+type WaitError struct {
+	// Err is the error of the waiting.
+	Err error
+}
+
+func (we *WaitError) Unwrap() error {
+	return we.Err
+}
+
+func (we *WaitError) Error() string {
+	return we.Err.Error()
+}
+
+// WaiterOptions returns the waiter options shared by the commands, they're built from the
+// flags of the root command and the logger stored in the context.
+func WaiterOptions(ctx context.Context) ([]waiter.Option, error) {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logger from context: %w", err)
+	}
+
+	return []waiter.Option{
+		waiter.WithTimeout(contextutil.GetTimeout(ctx)),
+		waiter.WithDeadline(contextutil.GetDeadline(ctx)),
+		waiter.WithAttemptTimeout(contextutil.GetAttemptTimeout(ctx)),
+		waiter.WithInterval(contextutil.GetInterval(ctx)),
+		waiter.WithInitialDelay(contextutil.GetInitialDelay(ctx)),
+		waiter.WithGracePeriod(contextutil.GetGracePeriod(ctx)),
+		waiter.WithInvertCheck(contextutil.GetInvertCheck(ctx)),
+		waiter.WithBackoffPolicy(contextutil.GetBackoffPolicy(ctx)),
+		waiter.WithBackoffJitter(contextutil.GetBackoffJitter(ctx)),
+		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(ctx)),
+		waiter.WithBackoffExponentialMaxInterval(contextutil.GetBackoffExponentialMaxInterval(ctx)),
+		waiter.WithSuccessThreshold(contextutil.GetSuccessThreshold(ctx)),
+		waiter.WithMaxConcurrency(contextutil.GetMaxConcurrency(ctx)),
+		waiter.WithLogger(logger),
+	}, nil
+}
+
+// WaitContext waits for the checkers of a command. It waits for all of them by default,
+// for the first ready one when the --any flag is set, or for at least --min-ready of them.
+// When the --watch flag is set, it keeps watching the checkers after they are ready, and
+// when the --name flag is set, the checkers are named after it.
+func WaitContext(ctx context.Context, checkers []checker.Checker, opts ...waiter.Option) error {
+	if name := contextutil.GetName(ctx); name != "" {
+		named := make([]checker.Checker, len(checkers))
+		for i, chk := range checkers {
+			named[i] = checker.WithLabels(chk, name, nil)
+		}
+		checkers = named
+	}
+
+	ctx, opts, finish, err := withOutputs(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return finish(wait(ctx, checkers, opts...))
+}
+
+// WaitGraphContext waits for the nodes of the graph command.
+func WaitGraphContext(ctx context.Context, nodes []waiter.Node, opts ...waiter.Option) error {
+	ctx, opts, finish, err := withOutputs(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return finish(waiter.WaitGraphContext(ctx, nodes, opts...))
+}
+
+// withOutputs registers the observers of the output flags, and the trace export when it's configured
+// by the OTEL_* environment variables. The returned finish function must be called with the result
+// of the waiting, it returns the error to report.
+func withOutputs(ctx context.Context, opts []waiter.Option) (context.Context, []waiter.Option, func(error) error, error) {
+	var finishers []func(error) error
+
+	collector := waiter.NewResultCollector()
+	opts = append(opts, waiter.WithObserver(collector))
+
+	// The output is set up first, so nothing is started yet when its format is invalid
+	if format := contextutil.GetOutput(ctx); format != "" && format != output.FormatText {
+		w, err := output.New(os.Stdout, format)
+		if err != nil {
+			return ctx, opts, nil, err
+		}
+
+		opts = append(opts, waiter.WithObserver(w))
+	}
+
+	// The metrics address is listened on before the waiting starts, so an address in use fails the command
+	if metricsFile, metricsAddr := contextutil.GetMetricsFile(ctx), contextutil.GetMetricsAddr(ctx); metricsFile != "" || metricsAddr != "" {
+		metricsCollector := metrics.New()
+		opts = append(opts, waiter.WithObserver(metricsCollector))
+
+		var server *http.Server
+		if metricsAddr != "" {
+			var err error
+			if server, err = serveMetrics(ctx, metricsAddr, metricsCollector); err != nil {
+				return ctx, opts, nil, err
+			}
+		}
+
+		finishers = append(finishers, func(err error) error {
+			if server != nil {
+				_ = server.Close()
+			}
+
+			if metricsFile != "" {
+				if metricsErr := metricsCollector.WriteFile(metricsFile); metricsErr != nil {
+					return errors.Join(err, fmt.Errorf("failed to write the metrics: %w", metricsErr))
+				}
+			}
+
+			return err
+		})
+	}
+
+	if tracing.Enabled() {
+		ctx = tracing.ContextWithParent(ctx)
+
+		tp, err := tracing.NewTracerProvider(ctx)
+		if err != nil {
+			logr.FromContextOrDiscard(ctx).Error(err, "Failed to set up the trace export")
+		} else {
+			opts = append(opts, waiter.WithTracerProvider(tp))
+			finishers = append(finishers, func(err error) error {
+				// The waiting context may be canceled already, the spans are flushed anyway
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+
+				if shutdownErr := tp.Shutdown(shutdownCtx); shutdownErr != nil {
+					logr.FromContextOrDiscard(ctx).Error(shutdownErr, "Failed to export the traces")
+				}
+
+				return err
+			})
+		}
+	}
+
+	if contextutil.GetProgress(ctx) {
+		renderer := progress.New(os.Stderr, contextutil.GetNoColor(ctx))
+		renderer.Start()
+
+		opts = append(opts, waiter.WithObserver(renderer))
+		finishers = append(finishers, func(err error) error {
+			renderer.Stop()
+			return err
+		})
+	}
+
+	if reports := contextutil.GetReports(ctx); len(reports) > 0 {
+		start := time.Now()
+
+		finishers = append(finishers, func(err error) error {
+			// The results follow the order of the checkers instead of the order they started
+			if reportErr := writeReports(reports, start, collector.Results()); reportErr != nil {
+				return errors.Join(err, reportErr)
+			}
+
+			return err
+		})
+	}
+
+	return ctx, opts, func(err error) error {
+		for _, finish := range finishers {
+			err = finish(err)
+		}
+
+		// The waiting started once a checker started
+		if err != nil && len(collector.Results()) > 0 {
+			return &WaitError{Err: err}
+		}
+
+		return err
+	}, nil
+}
+
+// wait waits for the checkers according to the flags.
+func wait(ctx context.Context, checkers []checker.Checker, opts ...waiter.Option) error {
+	if contextutil.GetWatch(ctx) {
+		opts = append(opts, waiter.WithFailureThreshold(contextutil.GetFailureThreshold(ctx)))
+
+		return waiter.WatchContext(ctx, checkers, opts...)
+	}
+
+	minReady := contextutil.GetMinReady(ctx)
+	if contextutil.GetAny(ctx) {
+		minReady = 1
+	}
+
+	if minReady == 0 {
+		return waiter.WaitParallelContext(ctx, checkers, opts...)
+	}
+
+	if minReady > len(checkers) {
+		return fmt.Errorf("--min-ready must be less than or equal to the number of addresses (%d)", len(checkers))
+	}
+
+	ready, err := waiter.WaitQuorumContext(ctx, checkers, minReady, opts...)
+	if err != nil {
+		return err
+	}
+
+	readyIDs := make([]string, len(ready))
+	for i, chk := range ready {
+		readyIDs[i], err = chk.Identity()
+		if err != nil {
+			return err
+		}
+	}
+
+	logr.FromContextOrDiscard(ctx).Info(fmt.Sprintf("%d of %d are ready: %s", len(ready), len(checkers), strings.Join(readyIDs, ", ")))
+
+	return nil
+}
+
+// writeReports writes the reports of the --report flag.
+func writeReports(reports []string, start time.Time, results []waiter.Result) error {
+	var errs []error
+	for _, value := range reports {
+		r, err := report.Parse(value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		switch r.Kind {
+		case report.KindJUnit:
+			errs = append(errs, writeJUnit(r.Path, start, results))
+		case report.KindSummary:
+			errs = append(errs, report.WriteSummary(os.Stderr, results))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// writeJUnit writes the JUnit XML report file.
+func writeJUnit(path string, start time.Time, results []waiter.Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create the junit report: %w", err)
+	}
+
+	if err := report.WriteJUnit(f, "wait4x", start, results); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write the junit report: %w", err)
+	}
+
+	return f.Close()
+}
+
+// serveMetrics serves the metrics at /metrics on the address until the returned server is closed.
+func serveMetrics(ctx context.Context, addr string, collector *metrics.Collector) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serve the metrics: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector)
+
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logr.FromContextOrDiscard(ctx).Error(err, "Failed to serve the metrics")
+		}
+	}()
+
+	return server, nil
+}
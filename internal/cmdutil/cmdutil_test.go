@@ -0,0 +1,50 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/contextutil"
+)
+
+func TestWaitContextInvalidOutput(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+
+	ctx := contextutil.WithOutput(context.Background(), "yaml")
+	err := WaitContext(ctx, []checker.Checker{mockChecker})
+
+	assert.EqualError(t, err, "invalid output format: yaml")
+	mockChecker.AssertNotCalled(t, "Check", mock.Anything)
+}
+
+func TestWaitContextMetricsAddrInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+
+	mockChecker := new(checker.MockChecker)
+
+	ctx := contextutil.WithMetricsAddr(context.Background(), ln.Addr().String())
+	err = WaitContext(ctx, []checker.Checker{mockChecker})
+
+	assert.ErrorContains(t, err, "failed to serve the metrics: listen tcp "+ln.Addr().String())
+	mockChecker.AssertNotCalled(t, "Check", mock.Anything)
+}
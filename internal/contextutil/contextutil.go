@@ -23,11 +23,29 @@ import (
 // These are context keys used to store and retrieve various values in the context.
 type (
 	timeoutCtxKey                       struct{}
+	attemptTimeoutCtxKey                struct{}
 	intervalCtxKey                      struct{}
 	invertCheckCtxKey                   struct{}
 	backoffPolicyCtxKey                 struct{}
+	backoffJitterCtxKey                 struct{}
 	backoffCoefficientCtxKey            struct{}
 	backoffExponentialMaxIntervalCtxKey struct{}
+	successThresholdCtxKey              struct{}
+	anyCtxKey                           struct{}
+	minReadyCtxKey                      struct{}
+	maxConcurrencyCtxKey                struct{}
+	watchCtxKey                         struct{}
+	failureThresholdCtxKey              struct{}
+	nameCtxKey                          struct{}
+	deadlineCtxKey                      struct{}
+	initialDelayCtxKey                  struct{}
+	gracePeriodCtxKey                   struct{}
+	progressCtxKey                      struct{}
+	noColorCtxKey                       struct{}
+	outputCtxKey                        struct{}
+	reportsCtxKey                       struct{}
+	metricsFileCtxKey                   struct{}
+	metricsAddrCtxKey                   struct{}
 )
 
 // WithTimeout returns a new context with the given timeout value.
@@ -43,6 +61,19 @@ func GetTimeout(ctx context.Context) time.Duration {
 	return 0
 }
 
+// WithAttemptTimeout returns a new context with the given attempt timeout value.
+func WithAttemptTimeout(ctx context.Context, attemptTimeout time.Duration) context.Context {
+	return context.WithValue(ctx, attemptTimeoutCtxKey{}, attemptTimeout)
+}
+
+// GetAttemptTimeout retrieves attempt timeout from context
+func GetAttemptTimeout(ctx context.Context) time.Duration {
+	if v := ctx.Value(attemptTimeoutCtxKey{}); v != nil {
+		return v.(time.Duration)
+	}
+	return 0
+}
+
 // WithInterval returns a new context with the given interval value.
 func WithInterval(ctx context.Context, interval time.Duration) context.Context {
 	return context.WithValue(ctx, intervalCtxKey{}, interval)
@@ -82,6 +113,19 @@ func GetBackoffPolicy(ctx context.Context) string {
 	return ""
 }
 
+// WithBackoffJitter returns a new context with the given backoff jitter value.
+func WithBackoffJitter(ctx context.Context, backoffJitter string) context.Context {
+	return context.WithValue(ctx, backoffJitterCtxKey{}, backoffJitter)
+}
+
+// GetBackoffJitter retrieves the backoff jitter from the given context.
+func GetBackoffJitter(ctx context.Context) string {
+	if v := ctx.Value(backoffJitterCtxKey{}); v != nil {
+		return v.(string)
+	}
+	return ""
+}
+
 // WithBackoffCoefficient returns a new context with the given backoff coefficient value.
 func WithBackoffCoefficient(ctx context.Context, backoffCoefficient float64) context.Context {
 	return context.WithValue(ctx, backoffCoefficientCtxKey{}, backoffCoefficient)
@@ -107,3 +151,211 @@ func GetBackoffExponentialMaxInterval(ctx context.Context) time.Duration {
 	}
 	return 0
 }
+
+// WithSuccessThreshold returns a new context with the given success threshold value.
+func WithSuccessThreshold(ctx context.Context, successThreshold int) context.Context {
+	return context.WithValue(ctx, successThresholdCtxKey{}, successThreshold)
+}
+
+// GetSuccessThreshold retrieves the success threshold from the given context.
+func GetSuccessThreshold(ctx context.Context) int {
+	if v := ctx.Value(successThresholdCtxKey{}); v != nil {
+		return v.(int)
+	}
+	return 1
+}
+
+// WithAny returns a new context with the given any value.
+func WithAny(ctx context.Context, anyReady bool) context.Context {
+	return context.WithValue(ctx, anyCtxKey{}, anyReady)
+}
+
+// GetAny retrieves the any value from the given context.
+func GetAny(ctx context.Context) bool {
+	if v := ctx.Value(anyCtxKey{}); v != nil {
+		return v.(bool)
+	}
+	return false
+}
+
+// WithMinReady returns a new context with the given min-ready value.
+func WithMinReady(ctx context.Context, minReady int) context.Context {
+	return context.WithValue(ctx, minReadyCtxKey{}, minReady)
+}
+
+// GetMinReady retrieves the min-ready value from the given context.
+func GetMinReady(ctx context.Context) int {
+	if v := ctx.Value(minReadyCtxKey{}); v != nil {
+		return v.(int)
+	}
+	return 0
+}
+
+// WithMaxConcurrency returns a new context with the given max concurrency value.
+func WithMaxConcurrency(ctx context.Context, maxConcurrency int) context.Context {
+	return context.WithValue(ctx, maxConcurrencyCtxKey{}, maxConcurrency)
+}
+
+// GetMaxConcurrency retrieves the max concurrency from the given context.
+func GetMaxConcurrency(ctx context.Context) int {
+	if v := ctx.Value(maxConcurrencyCtxKey{}); v != nil {
+		return v.(int)
+	}
+	return 0
+}
+
+// WithWatch returns a new context with the given watch value.
+func WithWatch(ctx context.Context, watch bool) context.Context {
+	return context.WithValue(ctx, watchCtxKey{}, watch)
+}
+
+// GetWatch retrieves the watch value from the given context.
+func GetWatch(ctx context.Context) bool {
+	if v := ctx.Value(watchCtxKey{}); v != nil {
+		return v.(bool)
+	}
+	return false
+}
+
+// WithFailureThreshold returns a new context with the given failure threshold value.
+func WithFailureThreshold(ctx context.Context, failureThreshold int) context.Context {
+	return context.WithValue(ctx, failureThresholdCtxKey{}, failureThreshold)
+}
+
+// GetFailureThreshold retrieves the failure threshold value from the given context.
+func GetFailureThreshold(ctx context.Context) int {
+	if v := ctx.Value(failureThresholdCtxKey{}); v != nil {
+		return v.(int)
+	}
+	return 1
+}
+
+// WithName returns a new context with the given checker name value.
+func WithName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, nameCtxKey{}, name)
+}
+
+// GetName retrieves the checker name value from the given context.
+func GetName(ctx context.Context) string {
+	if v := ctx.Value(nameCtxKey{}); v != nil {
+		return v.(string)
+	}
+	return ""
+}
+
+// WithDeadline returns a new context with the given deadline value.
+func WithDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, deadlineCtxKey{}, deadline)
+}
+
+// GetDeadline retrieves the deadline value from the given context.
+func GetDeadline(ctx context.Context) time.Time {
+	if v := ctx.Value(deadlineCtxKey{}); v != nil {
+		return v.(time.Time)
+	}
+	return time.Time{}
+}
+
+// WithInitialDelay returns a new context with the given initial delay value.
+func WithInitialDelay(ctx context.Context, initialDelay time.Duration) context.Context {
+	return context.WithValue(ctx, initialDelayCtxKey{}, initialDelay)
+}
+
+// GetInitialDelay retrieves the initial delay value from the given context.
+func GetInitialDelay(ctx context.Context) time.Duration {
+	if v := ctx.Value(initialDelayCtxKey{}); v != nil {
+		return v.(time.Duration)
+	}
+	return 0
+}
+
+// WithGracePeriod returns a new context with the given grace period value.
+func WithGracePeriod(ctx context.Context, gracePeriod time.Duration) context.Context {
+	return context.WithValue(ctx, gracePeriodCtxKey{}, gracePeriod)
+}
+
+// GetGracePeriod retrieves the grace period value from the given context.
+func GetGracePeriod(ctx context.Context) time.Duration {
+	if v := ctx.Value(gracePeriodCtxKey{}); v != nil {
+		return v.(time.Duration)
+	}
+	return 0
+}
+
+// WithProgress returns a new context with the given progress value.
+func WithProgress(ctx context.Context, progress bool) context.Context {
+	return context.WithValue(ctx, progressCtxKey{}, progress)
+}
+
+// GetProgress retrieves the progress value from the given context.
+func GetProgress(ctx context.Context) bool {
+	if v := ctx.Value(progressCtxKey{}); v != nil {
+		return v.(bool)
+	}
+	return false
+}
+
+// WithNoColor returns a new context with the given no color value.
+func WithNoColor(ctx context.Context, noColor bool) context.Context {
+	return context.WithValue(ctx, noColorCtxKey{}, noColor)
+}
+
+// GetNoColor retrieves the no color value from the given context.
+func GetNoColor(ctx context.Context) bool {
+	if v := ctx.Value(noColorCtxKey{}); v != nil {
+		return v.(bool)
+	}
+	return false
+}
+
+// WithOutput returns a new context with the given output format value.
+func WithOutput(ctx context.Context, output string) context.Context {
+	return context.WithValue(ctx, outputCtxKey{}, output)
+}
+
+// GetOutput retrieves the output format value from the given context.
+func GetOutput(ctx context.Context) string {
+	if v := ctx.Value(outputCtxKey{}); v != nil {
+		return v.(string)
+	}
+	return ""
+}
+
+// WithReports returns a new context with the given reports value.
+func WithReports(ctx context.Context, reports []string) context.Context {
+	return context.WithValue(ctx, reportsCtxKey{}, reports)
+}
+
+// GetReports retrieves the reports value from the given context.
+func GetReports(ctx context.Context) []string {
+	if v := ctx.Value(reportsCtxKey{}); v != nil {
+		return v.([]string)
+	}
+	return nil
+}
+
+// WithMetricsFile returns a new context with the given metrics file value.
+func WithMetricsFile(ctx context.Context, metricsFile string) context.Context {
+	return context.WithValue(ctx, metricsFileCtxKey{}, metricsFile)
+}
+
+// GetMetricsFile retrieves the metrics file value from the given context.
+func GetMetricsFile(ctx context.Context) string {
+	if v := ctx.Value(metricsFileCtxKey{}); v != nil {
+		return v.(string)
+	}
+	return ""
+}
+
+// WithMetricsAddr returns a new context with the given metrics address value.
+func WithMetricsAddr(ctx context.Context, metricsAddr string) context.Context {
+	return context.WithValue(ctx, metricsAddrCtxKey{}, metricsAddr)
+}
+
+// GetMetricsAddr retrieves the metrics address value from the given context.
+func GetMetricsAddr(ctx context.Context) string {
+	if v := ctx.Value(metricsAddrCtxKey{}); v != nil {
+		return v.(string)
+	}
+	return ""
+}
@@ -0,0 +1,274 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides the wait statistics in the Prometheus text exposition format.
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/waiter"
+)
+
+// These are the classes of the failed check attempts.
+const (
+	ClassExpectationFailed = "expectation_failed"
+	ClassConnectionRefused = "connection_refused"
+	ClassTimeout           = "timeout"
+	ClassError             = "error"
+)
+
+// series are the statistics of a single waiting.
+type series struct {
+	index       int
+	checkerType string
+	identity    string
+	start       time.Time
+	attempts    int
+	failures    map[string]int
+	ready       bool
+	done        bool
+	timeToReady time.Duration
+	elapsed     time.Duration
+}
+
+// Collector is a waiter.WaitingObserver which collects the statistics of the observed waitings.
+// It's safe for concurrent use, so the metrics can be served while the waiting goes on.
+type Collector struct {
+	waiter.NopObserver
+
+	mu     sync.Mutex
+	series []*series
+}
+
+// New creates the Collector
+func New() *Collector {
+	return &Collector{}
+}
+
+// NewWaiting starts collecting the statistics of a waiting.
+func (c *Collector) NewWaiting(_ checker.Checker, index int) waiter.Observer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := &series{index: index, failures: make(map[string]int)}
+	c.series = append(c.series, s)
+
+	return &seriesObserver{collector: c, series: s}
+}
+
+// seriesObserver records the events of a waiting to its series.
+type seriesObserver struct {
+	collector *Collector
+	series    *series
+}
+
+// OnStart records the checker of the waiting.
+func (so *seriesObserver) OnStart(chk checker.Checker) {
+	id, _ := chk.Identity()
+
+	so.collector.mu.Lock()
+	defer so.collector.mu.Unlock()
+
+	so.series.checkerType = waiter.CheckerType(chk)
+	so.series.identity = id
+	so.series.start = time.Now()
+}
+
+// OnAttempt counts the attempt, and the failure by its class.
+func (so *seriesObserver) OnAttempt(_ checker.Checker, attempt waiter.Attempt) {
+	so.collector.mu.Lock()
+	defer so.collector.mu.Unlock()
+
+	so.series.attempts++
+	if attempt.Err != nil {
+		so.series.failures[Classify(attempt.Err)]++
+	}
+}
+
+// OnReady records the time to ready.
+func (so *seriesObserver) OnReady(_ checker.Checker, _ int, elapsed time.Duration) {
+	so.collector.mu.Lock()
+	defer so.collector.mu.Unlock()
+
+	so.series.ready = true
+	so.series.done = true
+	so.series.timeToReady = elapsed
+	so.series.elapsed = elapsed
+}
+
+// OnGiveUp records the end of the waiting.
+func (so *seriesObserver) OnGiveUp(_ checker.Checker, _ int, elapsed time.Duration, _ error) {
+	so.collector.mu.Lock()
+	defer so.collector.mu.Unlock()
+
+	so.series.done = true
+	so.series.elapsed = elapsed
+}
+
+// Classify returns the class of the error of a failed check attempt.
+func Classify(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ClassTimeout
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if checker.IsConnectionRefused(e) {
+			return ClassConnectionRefused
+		}
+	}
+
+	var expectedError *checker.ExpectedError
+	if errors.As(err, &expectedError) {
+		return ClassExpectationFailed
+	}
+
+	return ClassError
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var b bytes.Buffer
+	now := time.Now()
+
+	// The series follow the order of the checkers, the waitings which failed before they started
+	// have no series
+	ordered := make([]*series, 0, len(c.series))
+	for _, s := range c.series {
+		if !s.start.IsZero() {
+			ordered = append(ordered, s)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].index < ordered[j].index
+	})
+
+	family(&b, "wait4x_attempts_total", "counter", "Number of check attempts.")
+	for _, s := range ordered {
+		sample(&b, "wait4x_attempts_total", s.labels(), float64(s.attempts))
+	}
+
+	family(&b, "wait4x_failures_total", "counter", "Number of failed check attempts by error class.")
+	for _, s := range ordered {
+		classes := make([]string, 0, len(s.failures))
+		for class := range s.failures {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+
+		for _, class := range classes {
+			sample(&b, "wait4x_failures_total", append(s.labels(), "class", class), float64(s.failures[class]))
+		}
+	}
+
+	family(&b, "wait4x_time_to_ready_seconds", "gauge", "Time it took the target to become ready.")
+	for _, s := range ordered {
+		if s.ready {
+			sample(&b, "wait4x_time_to_ready_seconds", s.labels(), s.timeToReady.Seconds())
+		}
+	}
+
+	family(&b, "wait4x_elapsed_seconds", "gauge", "Time spent waiting for the target.")
+	for _, s := range ordered {
+		elapsed := s.elapsed
+		if !s.done {
+			elapsed = now.Sub(s.start)
+		}
+		sample(&b, "wait4x_elapsed_seconds", s.labels(), elapsed.Seconds())
+	}
+
+	family(&b, "wait4x_ready", "gauge", "Whether the target is ready (1) or not (0).")
+	for _, s := range ordered {
+		ready := 0.0
+		if s.ready {
+			ready = 1
+		}
+		sample(&b, "wait4x_ready", s.labels(), ready)
+	}
+
+	return b.WriteTo(w)
+}
+
+// ServeHTTP serves the metrics.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = c.WriteTo(w)
+}
+
+// WriteFile writes the metrics to the file for the textfile collector of the node exporter.
+// The file is replaced atomically, so the collector never reads a partial file.
+func (c *Collector) WriteFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// The temporary file is only readable by its owner, the collector may run as another user
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err := c.WriteTo(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// labels returns the labels of the series. The index tells apart the waitings of the same target,
+// e.g. wait4x tcp a:1 a:1, which would have the same labels otherwise.
+func (s *series) labels() []string {
+	return []string{"checker", s.checkerType, "identity", s.identity, "index", strconv.Itoa(s.index)}
+}
+
+func family(b *bytes.Buffer, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func sample(b *bytes.Buffer, name string, labels []string, value float64) {
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[i], escape(labels[i+1])))
+	}
+
+	fmt.Fprintf(b, "%s{%s} %g\n", name, strings.Join(pairs, ","), value)
+}
+
+// escape escapes the backslashes, double quotes and line feeds of a label value.
+func escape(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
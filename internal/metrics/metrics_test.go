@@ -0,0 +1,111 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/waiter"
+)
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, ClassTimeout, Classify(context.DeadlineExceeded))
+	assert.Equal(t, ClassTimeout, Classify(fmt.Errorf("dial: %w", context.DeadlineExceeded)))
+	assert.Equal(t, ClassConnectionRefused, Classify(fmt.Errorf("dial: %w", syscall.ECONNREFUSED)))
+	assert.Equal(t, ClassExpectationFailed, Classify(checker.NewExpectedError("unexpected status code", nil)))
+	assert.Equal(t, ClassError, Classify(errors.New("error")))
+}
+
+func TestCollector(t *testing.T) {
+	chk := new(checker.MockChecker)
+	chk.On("Check", mock.Anything).Return(checker.NewExpectedError("failed", nil)).Once().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	collector := New()
+	assert.NoError(t, waiter.Wait(chk, waiter.WithInterval(time.Millisecond), waiter.WithObserver(collector)))
+
+	var buf bytes.Buffer
+	_, err := collector.WriteTo(&buf)
+	assert.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "# TYPE wait4x_attempts_total counter\n")
+	assert.Contains(t, buf.String(), `wait4x_attempts_total{checker="MockChecker",identity="ID",index="0"} 2`)
+	assert.Contains(t, buf.String(), `wait4x_failures_total{checker="MockChecker",identity="ID",index="0",class="expectation_failed"} 1`)
+	assert.Contains(t, buf.String(), `wait4x_time_to_ready_seconds{checker="MockChecker",identity="ID",index="0"} `)
+	assert.Contains(t, buf.String(), `wait4x_ready{checker="MockChecker",identity="ID",index="0"} 1`)
+
+	// The same checker waited for twice has series of its own
+	collector = New()
+	assert.NoError(t, waiter.WaitParallel([]checker.Checker{chk, chk}, waiter.WithObserver(collector)))
+
+	buf.Reset()
+	_, err = collector.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `wait4x_ready{checker="MockChecker",identity="ID",index="0"} 1`)
+	assert.Contains(t, buf.String(), `wait4x_ready{checker="MockChecker",identity="ID",index="1"} 1`)
+
+	rec := httptest.NewRecorder()
+	collector.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Contains(t, rec.Body.String(), `wait4x_ready{checker="MockChecker",identity="ID",index="1"} 1`)
+
+	// The waiting which failed before it started has no series
+	invalidIdentity := new(checker.MockChecker)
+	invalidIdentity.On("Identity").Return("", errors.New("invalid identity"))
+
+	collector = New()
+	assert.Error(t, waiter.WaitParallel([]checker.Checker{chk, invalidIdentity}, waiter.WithObserver(collector)))
+
+	buf.Reset()
+	_, err = collector.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(buf.String(), "wait4x_ready{"))
+}
+
+func TestCollectorNotReady(t *testing.T) {
+	chk := new(checker.MockChecker)
+	chk.On("Check", mock.Anything).Return(errors.New("error")).
+		On("Identity").Return("ID\"", nil)
+
+	collector := New()
+	err := waiter.Wait(chk, waiter.WithTimeout(20*time.Millisecond), waiter.WithInterval(5*time.Millisecond), waiter.WithObserver(collector))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	path := filepath.Join(t.TempDir(), "wait4x.prom")
+	assert.NoError(t, collector.WriteFile(path))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `wait4x_ready{checker="MockChecker",identity="ID\"",index="0"} 0`)
+	assert.Contains(t, string(content), `class="error"`)
+	assert.NotContains(t, string(content), "wait4x_time_to_ready_seconds{")
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+}
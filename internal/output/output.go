@@ -0,0 +1,246 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output provides the machine-readable event stream of the waiting.
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/waiter"
+)
+
+const (
+	// FormatText is the human-readable log output.
+	FormatText = "text"
+	// FormatJSON is the newline-delimited JSON event stream.
+	FormatJSON = "json"
+	// FormatLogfmt is the logfmt event stream.
+	FormatLogfmt = "logfmt"
+)
+
+// These are the event types of the stream.
+const (
+	EventAttempt           = "attempt"
+	EventExpectationFailed = "expectation-failed"
+	EventReady             = "ready"
+	EventTimeout           = "timeout"
+	EventGiveUp            = "give-up"
+	EventTransition        = "transition"
+)
+
+// field is a key/value pair of an event, fields keep their order in the output.
+type field struct {
+	key   string
+	value any
+}
+
+// Writer is a waiter.Observer which writes an event per line for each step of the waiting.
+type Writer struct {
+	mu     sync.Mutex
+	out    io.Writer
+	encode func(fields []field) ([]byte, error)
+}
+
+// New creates the Writer of the given format, json or logfmt.
+func New(out io.Writer, format string) (*Writer, error) {
+	w := &Writer{out: out}
+
+	switch format {
+	case FormatJSON:
+		w.encode = encodeJSON
+	case FormatLogfmt:
+		w.encode = encodeLogfmt
+	default:
+		return nil, fmt.Errorf("invalid output format: %s", format)
+	}
+
+	return w, nil
+}
+
+// OnStart does nothing, the stream starts with the first attempt.
+func (w *Writer) OnStart(checker.Checker) {}
+
+// OnAttempt writes an attempt event, or an expectation-failed event when the check failed
+// with a checker.ExpectedError.
+func (w *Writer) OnAttempt(chk checker.Checker, attempt waiter.Attempt) {
+	fields := []field{
+		{"attempt", attempt.Number},
+		{"duration_ms", attempt.Duration.Milliseconds()},
+	}
+
+	var expectedError *checker.ExpectedError
+	if errors.As(attempt.Err, &expectedError) {
+		fields = append(fields, field{"error", expectedError.Error()}, field{"details", details(expectedError.Details())})
+		w.write(EventExpectationFailed, chk, fields...)
+
+		return
+	}
+
+	if attempt.Err != nil {
+		fields = append(fields, field{"error", attempt.Err.Error()})
+	}
+	w.write(EventAttempt, chk, fields...)
+}
+
+// OnReady writes a ready event.
+func (w *Writer) OnReady(chk checker.Checker, attempts int, elapsed time.Duration) {
+	w.write(EventReady, chk, field{"attempts", attempts}, field{"elapsed_ms", elapsed.Milliseconds()})
+}
+
+// OnGiveUp writes a timeout event when the time ran out, otherwise a give-up event.
+func (w *Writer) OnGiveUp(chk checker.Checker, attempts int, elapsed time.Duration, err error) {
+	event := EventGiveUp
+	if errors.Is(err, context.DeadlineExceeded) {
+		event = EventTimeout
+	}
+
+	fields := []field{{"attempts", attempts}, {"elapsed_ms", elapsed.Milliseconds()}}
+	if err != nil {
+		fields = append(fields, field{"error", err.Error()})
+	}
+	w.write(event, chk, fields...)
+}
+
+// OnTransition writes a transition event of a watched checker.
+func (w *Writer) OnTransition(chk checker.Checker, transition waiter.Transition) {
+	fields := []field{
+		{"from", string(transition.From)},
+		{"to", string(transition.To)},
+		{"failures", transition.Failures},
+	}
+	if transition.Err != nil {
+		fields = append(fields, field{"error", transition.Err.Error()})
+	}
+	w.write(EventTransition, chk, fields...)
+}
+
+// write encodes the event with the common fields and writes it as a single line.
+func (w *Writer) write(event string, chk checker.Checker, fields ...field) {
+	id, _ := chk.Identity()
+
+	common := []field{
+		{"time", time.Now().UTC().Format(time.RFC3339Nano)},
+		{"event", event},
+		{"name", waiter.CheckerName(chk)},
+		{"identity", id},
+	}
+	if named, ok := chk.(checker.Named); ok && len(named.Labels()) > 0 {
+		common = append(common, field{"labels", named.Labels()})
+	}
+
+	line, err := w.encode(append(common, fields...))
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, _ = w.out.Write(append(line, '\n'))
+}
+
+// details converts the key/value details of an ExpectedError to a map.
+func details(keysAndValues []any) map[string]any {
+	m := make(map[string]any, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		m[fmt.Sprint(keysAndValues[i])] = detailValue(keysAndValues[i+1])
+	}
+
+	return m
+}
+
+// detailValue keeps the JSON friendly values and converts the others to strings.
+func detailValue(v any) any {
+	switch v := v.(type) {
+	case nil, bool, string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func encodeJSON(fields []field) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("{")
+	for i, f := range fields {
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, "%q:%s", f.key, value)
+	}
+	b.WriteString("}")
+
+	return []byte(b.String()), nil
+}
+
+func encodeLogfmt(fields []field) ([]byte, error) {
+	pairs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		// Maps are flattened with the dotted keys, e.g. details.timeout=3s
+		switch m := f.value.(type) {
+		case map[string]any:
+			for _, k := range sortedKeys(m) {
+				pairs = append(pairs, f.key+"."+k+"="+logfmtValue(m[k]))
+			}
+		case map[string]string:
+			for _, k := range sortedKeys(m) {
+				pairs = append(pairs, f.key+"."+k+"="+logfmtValue(m[k]))
+			}
+		default:
+			pairs = append(pairs, f.key+"="+logfmtValue(f.value))
+		}
+	}
+
+	return []byte(strings.Join(pairs, " ")), nil
+}
+
+// logfmtValue quotes the value when it's empty or contains spaces, quotes or equal signs.
+func logfmtValue(v any) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
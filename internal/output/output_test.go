@@ -0,0 +1,110 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/waiter"
+)
+
+func newMockChecker() checker.Checker {
+	chk := new(checker.MockChecker)
+	chk.On("Identity").Return("127.0.0.1:5432", nil)
+
+	return checker.WithLabels(chk, "db", map[string]string{"env": "ci"})
+}
+
+func TestJSON(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(&buf, FormatJSON)
+	assert.NoError(t, err)
+
+	chk := newMockChecker()
+	w.OnAttempt(chk, waiter.Attempt{Number: 1, Duration: 20 * time.Millisecond, Err: checker.NewExpectedError("unexpected status code", nil, "actual", 503, "timeout", time.Second)})
+	w.OnAttempt(chk, waiter.Attempt{Number: 2, Duration: 10 * time.Millisecond, Err: errors.New("error")})
+	w.OnReady(chk, 3, 2*time.Second)
+	w.OnGiveUp(chk, 3, 10*time.Second, context.DeadlineExceeded)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 4)
+
+	var events []map[string]any
+	for _, line := range lines {
+		var event map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+
+	assert.Equal(t, EventExpectationFailed, events[0]["event"])
+	assert.Equal(t, "db", events[0]["name"])
+	assert.Equal(t, "127.0.0.1:5432", events[0]["identity"])
+	assert.Equal(t, map[string]any{"env": "ci"}, events[0]["labels"])
+	assert.Equal(t, map[string]any{"actual": float64(503), "timeout": "1s"}, events[0]["details"])
+	assert.Equal(t, float64(20), events[0]["duration_ms"])
+
+	assert.Equal(t, EventAttempt, events[1]["event"])
+	assert.Equal(t, "error", events[1]["error"])
+
+	assert.Equal(t, EventReady, events[2]["event"])
+	assert.Equal(t, float64(3), events[2]["attempts"])
+	assert.Equal(t, float64(2000), events[2]["elapsed_ms"])
+
+	assert.Equal(t, EventTimeout, events[3]["event"])
+	assert.True(t, strings.HasPrefix(lines[3], `{"time":`), "the fields keep their order")
+}
+
+func TestLogfmt(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(&buf, FormatLogfmt)
+	assert.NoError(t, err)
+
+	chk := newMockChecker()
+	w.OnAttempt(chk, waiter.Attempt{Number: 1, Err: checker.NewExpectedError("unexpected status code", nil, "actual", 503)})
+	w.OnTransition(chk, waiter.Transition{From: waiter.StateReady, To: waiter.StateDegraded, Failures: 1})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], ` event=expectation-failed name=db identity=127.0.0.1:5432 labels.env=ci attempt=1 duration_ms=0 error="unexpected status code" details.actual=503`)
+	assert.Contains(t, lines[1], ` event=transition name=db identity=127.0.0.1:5432 labels.env=ci from=ready to=degraded failures=1`)
+}
+
+func TestWriterWithWaiter(t *testing.T) {
+	chk := new(checker.MockChecker)
+	chk.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	var buf bytes.Buffer
+	w, err := New(&buf, FormatJSON)
+	assert.NoError(t, err)
+
+	assert.NoError(t, waiter.Wait(chk, waiter.WithObserver(w)))
+	assert.Contains(t, buf.String(), `"event":"attempt","name":"MockChecker","identity":"ID","attempt":1`)
+	assert.Contains(t, buf.String(), `"event":"ready"`)
+}
+
+func TestInvalidFormat(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "xml")
+	assert.EqualError(t, err, "invalid output format: xml")
+}
@@ -0,0 +1,305 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package progress provides a live terminal view of the waiting progress.
+package progress
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/waiter"
+)
+
+// refreshInterval is the time between two renders of the progress view.
+const refreshInterval = 100 * time.Millisecond
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// IsTerminal reports whether the file is a terminal, the progress view is only useful on terminals.
+func IsTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// status is the progress of a single waiting.
+type status struct {
+	index    int
+	name     string
+	attempts int
+	start    time.Time
+	end      time.Time
+	ready    bool
+	gaveUp   bool
+	message  string
+}
+
+// Renderer is a waiter.WaitingObserver which renders one line per checker with a spinner, the attempt
+// count, the elapsed time and the last expectation failure, updating the lines in place.
+type Renderer struct {
+	waiter.NopObserver
+
+	mu       sync.Mutex
+	out      io.Writer
+	statuses []*status
+	frame    int
+	// rendered is the number of lines written by the previous render
+	rendered int
+	// width returns the number of columns of the terminal, 0 when unknown
+	width func() int
+
+	spinner *color.Color
+	success *color.Color
+	failure *color.Color
+	faint   *color.Color
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates the Renderer writing to out. The lines are cut to the width of the terminal when
+// out is a terminal, so none of them wraps and the cursor moves back to the first line on each render.
+func New(out io.Writer, noColor bool) *Renderer {
+	r := &Renderer{
+		out:     out,
+		width:   terminalWidth(out),
+		spinner: color.New(color.FgCyan),
+		success: color.New(color.FgGreen),
+		failure: color.New(color.FgRed),
+		faint:   color.New(color.Faint),
+	}
+
+	for _, c := range []*color.Color{r.spinner, r.success, r.failure, r.faint} {
+		if noColor {
+			c.DisableColor()
+		} else {
+			c.EnableColor()
+		}
+	}
+
+	return r
+}
+
+// Start renders the progress view periodically until Stop is called.
+func (r *Renderer) Start() {
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.render()
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic rendering and renders the final state.
+func (r *Renderer) Stop() {
+	if r.stop != nil {
+		close(r.stop)
+		<-r.done
+	}
+
+	r.render()
+}
+
+// NewWaiting adds the line of a waiting, the lines are in the order of the checkers.
+func (r *Renderer) NewWaiting(chk checker.Checker, index int) waiter.Observer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := &status{index: index, name: displayName(chk), start: time.Now()}
+
+	at := len(r.statuses)
+	for at > 0 && r.statuses[at-1].index > index {
+		at--
+	}
+	r.statuses = slices.Insert(r.statuses, at, s)
+
+	return &statusObserver{renderer: r, status: s}
+}
+
+// statusObserver updates the line of a waiting.
+type statusObserver struct {
+	renderer *Renderer
+	status   *status
+}
+
+// OnStart starts measuring the elapsed time of the waiting.
+func (so *statusObserver) OnStart(checker.Checker) {
+	so.renderer.mu.Lock()
+	defer so.renderer.mu.Unlock()
+
+	so.status.start = time.Now()
+}
+
+// OnAttempt updates the attempt count and the last expectation failure of the checker.
+func (so *statusObserver) OnAttempt(_ checker.Checker, attempt waiter.Attempt) {
+	so.renderer.mu.Lock()
+	defer so.renderer.mu.Unlock()
+
+	so.status.attempts = attempt.Number
+
+	var expectedError *checker.ExpectedError
+	if errors.As(attempt.Err, &expectedError) {
+		so.status.message = expectedError.Error()
+	}
+}
+
+// OnReady marks the checker as ready.
+func (so *statusObserver) OnReady(checker.Checker, int, time.Duration) {
+	so.renderer.mu.Lock()
+	defer so.renderer.mu.Unlock()
+
+	so.status.ready = true
+	so.status.end = time.Now()
+}
+
+// OnGiveUp marks the checker as failed.
+func (so *statusObserver) OnGiveUp(_ checker.Checker, _ int, _ time.Duration, err error) {
+	so.renderer.mu.Lock()
+	defer so.renderer.mu.Unlock()
+
+	so.status.gaveUp = true
+	so.status.end = time.Now()
+	if so.status.message == "" && err != nil {
+		so.status.message = err.Error()
+	}
+}
+
+// render writes the current state over the previously rendered lines.
+func (r *Renderer) render() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+
+	// Move the cursor back to the first line of the previous render
+	if r.rendered > 0 {
+		fmt.Fprintf(&b, "\033[%dA", r.rendered)
+	}
+
+	now := time.Now()
+	for _, s := range r.statuses {
+		var icon string
+		switch {
+		case s.ready:
+			icon = r.success.Sprint("✔")
+		case s.gaveUp:
+			icon = r.failure.Sprint("✘")
+		default:
+			icon = r.spinner.Sprint(spinnerFrames[r.frame%len(spinnerFrames)])
+		}
+
+		end := now
+		if !s.end.IsZero() {
+			end = s.end
+		}
+
+		// The icon and the spaces between the parts are a column each
+		columns := -1
+		if width := r.width(); width > 0 {
+			columns = max(width-2, 0)
+		}
+		name := fit(s.name, &columns)
+		stats := fit(fmt.Sprintf("attempts: %d  elapsed: %s", s.attempts, end.Sub(s.start).Truncate(100*time.Millisecond)), &columns)
+
+		// Clear the line before writing, it may be shorter than the previous one
+		fmt.Fprintf(&b, "\033[2K%s %s", icon, name)
+		if stats != "" {
+			fmt.Fprintf(&b, " %s", r.faint.Sprint(stats))
+		}
+		if s.message != "" && !s.ready {
+			// The message is separated by two spaces
+			if columns > 0 {
+				columns--
+			}
+			if message := fit(s.message, &columns); message != "" {
+				fmt.Fprintf(&b, "  %s", r.failure.Sprint(message))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	r.frame++
+	r.rendered = len(r.statuses)
+
+	_, _ = io.WriteString(r.out, b.String())
+}
+
+// terminalWidth returns the function reporting the width of out, it reports 0 when out isn't a terminal.
+// The width is read on each call, so a resized terminal is taken into account.
+func terminalWidth(out io.Writer) func() int {
+	f, ok := out.(*os.File)
+	if !ok || !IsTerminal(f) {
+		return func() int { return 0 }
+	}
+
+	return func() int {
+		width, _, err := term.GetSize(int(f.Fd()))
+		if err != nil {
+			return 0
+		}
+
+		return width
+	}
+}
+
+// fit cuts s to the remaining columns and counts the columns it takes with the separator before
+// the next part, negative columns mean unlimited.
+func fit(s string, columns *int) string {
+	if *columns < 0 {
+		return s
+	}
+
+	runes := []rune(s)
+	if len(runes) > *columns {
+		runes = runes[:max(*columns, 0)]
+		if len(runes) > 0 {
+			runes[len(runes)-1] = '…'
+		}
+	}
+
+	*columns = max(*columns-len(runes)-1, 0)
+
+	return string(runes)
+}
+
+// displayName returns the name and the identity of the checker.
+func displayName(chk checker.Checker) string {
+	id, _ := chk.Identity()
+	if named, ok := chk.(checker.Named); ok && named.Name() != "" {
+		return fmt.Sprintf("[%s] %s", named.Name(), id)
+	}
+
+	return id
+}
@@ -0,0 +1,101 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/waiter"
+)
+
+func TestRenderer(t *testing.T) {
+	ready := new(checker.MockChecker)
+	ready.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("127.0.0.1:6379", nil)
+
+	failing := new(checker.MockChecker)
+	failing.On("Check", mock.Anything).Return(checker.NewExpectedError("failed to establish a tcp connection", nil)).
+		On("Identity").Return("127.0.0.1:5432", nil)
+
+	db := checker.WithLabels(failing, "db", nil)
+
+	var buf bytes.Buffer
+	renderer := New(&buf, true)
+
+	err := waiter.WaitParallel(
+		[]checker.Checker{ready, db},
+		waiter.WithTimeout(50*time.Millisecond),
+		waiter.WithInterval(10*time.Millisecond),
+		waiter.WithObserver(renderer),
+	)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	renderer.Stop()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, buf.String(), "✔ 127.0.0.1:6379 attempts: 1")
+	assert.Contains(t, buf.String(), fmt.Sprintf("✘ [db] 127.0.0.1:5432 attempts: %d", renderer.statuses[1].attempts))
+	assert.Contains(t, buf.String(), "failed to establish a tcp connection")
+	assert.NotContains(t, buf.String(), "\033[3", "no colors are expected")
+
+	// The next render overwrites the previous lines
+	buf.Reset()
+	renderer.render()
+	assert.True(t, strings.HasPrefix(buf.String(), "\033[2A"))
+}
+
+func TestRendererTerminalWidth(t *testing.T) {
+	failing := new(checker.MockChecker)
+	failing.On("Check", mock.Anything).Return(checker.NewExpectedError("the status code doesn't expect, actual 503", nil)).
+		On("Identity").Return("http://localhost:8080/health", nil)
+
+	for _, width := range []int{10, 30, 60} {
+		var buf bytes.Buffer
+		renderer := New(&buf, true)
+		renderer.width = func() int { return width }
+
+		err := waiter.Wait(failing, waiter.WithTimeout(20*time.Millisecond), waiter.WithInterval(10*time.Millisecond), waiter.WithObserver(renderer))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		renderer.Stop()
+
+		// Long lines are cut, so they don't wrap over the next one
+		line := strings.TrimPrefix(strings.TrimSuffix(buf.String(), "\n"), "\033[2K")
+		assert.LessOrEqual(t, utf8.RuneCountInString(line), width, line)
+	}
+}
+
+func TestFit(t *testing.T) {
+	columns := -1
+	assert.Equal(t, "unlimited", fit("unlimited", &columns))
+	assert.Equal(t, -1, columns)
+
+	columns = 6
+	assert.Equal(t, "abc", fit("abc", &columns))
+	assert.Equal(t, 2, columns)
+	assert.Equal(t, "d…", fit("defgh", &columns))
+	assert.Equal(t, 0, columns)
+	assert.Equal(t, "", fit("ijk", &columns))
+}
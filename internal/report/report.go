@@ -0,0 +1,218 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package report provides the reports written at the end of the waiting.
+package report
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/waiter"
+)
+
+const (
+	// KindJUnit is the JUnit XML report, written to a file.
+	KindJUnit = "junit"
+	// KindSummary is the summary table, written to stderr.
+	KindSummary = "summary"
+)
+
+// Report is a report requested by the --report flag.
+type Report struct {
+	// Kind is the kind of the report.
+	Kind string
+	// Path is the path of the report file, empty for the summary.
+	Path string
+}
+
+// Parse parses a report in the KIND[=PATH] format, e.g. junit=report.xml or summary.
+func Parse(value string) (Report, error) {
+	kind, path, _ := strings.Cut(value, "=")
+
+	switch kind {
+	case KindJUnit:
+		if path == "" {
+			return Report{}, fmt.Errorf("the %s report requires a path, e.g. %s=report.xml", kind, kind)
+		}
+	case KindSummary:
+		if path != "" {
+			return Report{}, fmt.Errorf("the %s report doesn't accept a path", kind)
+		}
+	default:
+		return Report{}, fmt.Errorf("invalid report %q, expected %s=PATH or %s", value, KindJUnit, KindSummary)
+	}
+
+	return Report{Kind: kind, Path: path}, nil
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the results as a JUnit XML report with one test case per checker.
+// The time of a ready checker is its time to ready, otherwise the whole waiting time.
+// The checkers canceled on purpose, e.g. by the --any mode, are skipped instead of failed.
+func WriteJUnit(w io.Writer, name string, start time.Time, results []waiter.Result) error {
+	suite := junitTestSuite{
+		Name:      name,
+		Tests:     len(results),
+		Timestamp: start.UTC().Format(time.RFC3339),
+	}
+
+	var total time.Duration
+	for _, r := range results {
+		tc := junitTestCase{
+			Name:      r.Identity,
+			Classname: r.Name,
+			Time:      seconds(r.Elapsed),
+		}
+
+		if r.Elapsed > total {
+			total = r.Elapsed
+		}
+
+		switch {
+		case r.Canceled:
+			suite.Skipped++
+			tc.Skipped = &junitSkipped{Message: "canceled"}
+		case !r.Ready:
+			suite.Failures++
+			tc.Failure = newJUnitFailure(r)
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Time = seconds(total)
+
+	report := junitTestSuites{
+		Name:     name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// newJUnitFailure describes why the checker isn't ready, with the details of the last expectation failure.
+func newJUnitFailure(r waiter.Result) *junitFailure {
+	f := &junitFailure{
+		Message: "not ready",
+		Type:    "error",
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "attempts: %d\n", r.Attempts)
+
+	if r.LastError != nil {
+		f.Message = r.LastError.Error()
+
+		var expectedError *checker.ExpectedError
+		if errors.As(r.LastError, &expectedError) {
+			f.Type = "expectation-failed"
+
+			details := expectedError.Details()
+			for i := 0; i+1 < len(details); i += 2 {
+				fmt.Fprintf(&body, "%v: %v\n", details[i], details[i+1])
+			}
+		}
+	}
+	f.Body = body.String()
+
+	return f
+}
+
+// WriteSummary writes the results as a table.
+func WriteSummary(w io.Writer, results []waiter.Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "NAME\tIDENTITY\tSTATUS\tATTEMPTS\tTIME\tLAST ERROR")
+	for _, r := range results {
+		status := "ready"
+		switch {
+		case r.Canceled:
+			status = "canceled"
+		case !r.Ready:
+			status = "not ready"
+		}
+
+		lastError := "-"
+		if r.LastError != nil {
+			lastError = r.LastError.Error()
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", r.Name, r.Identity, status, r.Attempts, r.Elapsed.Round(time.Millisecond), lastError)
+	}
+
+	return tw.Flush()
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,117 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/waiter"
+)
+
+var results = []waiter.Result{
+	{
+		Name:        "TCP",
+		Identity:    "127.0.0.1:6379",
+		Ready:       true,
+		Attempts:    2,
+		TimeToReady: 1500 * time.Millisecond,
+		Elapsed:     1500 * time.Millisecond,
+	},
+	{
+		Name:      "HTTP",
+		Identity:  "http://localhost:8080",
+		Attempts:  5,
+		Elapsed:   10 * time.Second,
+		LastError: checker.NewExpectedError("the status code doesn't expect", nil, "actual", 503, "expect", 200),
+	},
+	{
+		Name:     "TCP",
+		Identity: "127.0.0.1:5432",
+		Canceled: true,
+		Attempts: 1,
+		Elapsed:  time.Second,
+	},
+}
+
+func TestParse(t *testing.T) {
+	r, err := Parse("junit=report.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, Report{Kind: KindJUnit, Path: "report.xml"}, r)
+
+	r, err = Parse("summary")
+	assert.NoError(t, err)
+	assert.Equal(t, Report{Kind: KindSummary}, r)
+
+	_, err = Parse("junit")
+	assert.EqualError(t, err, "the junit report requires a path, e.g. junit=report.xml")
+
+	_, err = Parse("summary=out.txt")
+	assert.EqualError(t, err, "the summary report doesn't accept a path")
+
+	_, err = Parse("html=report.html")
+	assert.EqualError(t, err, `invalid report "html=report.html", expected junit=PATH or summary`)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteJUnit(&buf, "wait4x", time.Date(2025, 3, 14, 14, 5, 0, 0, time.UTC), results)
+	assert.NoError(t, err)
+
+	var report junitTestSuites
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, 3, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, "10.000", report.Time)
+
+	suite := report.Suites[0]
+	assert.Equal(t, "2025-03-14T14:05:00Z", suite.Timestamp)
+	assert.Len(t, suite.Cases, 3)
+
+	assert.Equal(t, "127.0.0.1:6379", suite.Cases[0].Name)
+	assert.Equal(t, "TCP", suite.Cases[0].Classname)
+	assert.Equal(t, "1.500", suite.Cases[0].Time)
+	assert.Nil(t, suite.Cases[0].Failure)
+
+	failure := suite.Cases[1].Failure
+	assert.NotNil(t, failure)
+	assert.Equal(t, "the status code doesn't expect", failure.Message)
+	assert.Equal(t, "expectation-failed", failure.Type)
+	assert.Equal(t, "attempts: 5\nactual: 503\nexpect: 200\n", failure.Body)
+
+	// The canceled checker is skipped, not failed
+	assert.Nil(t, suite.Cases[2].Failure)
+	if assert.NotNil(t, suite.Cases[2].Skipped) {
+		assert.Equal(t, "canceled", suite.Cases[2].Skipped.Message)
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSummary(&buf, results))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, "NAME  IDENTITY               STATUS     ATTEMPTS  TIME  LAST ERROR", lines[0])
+	assert.Equal(t, "TCP   127.0.0.1:6379         ready      2         1.5s  -", lines[1])
+	assert.Equal(t, "HTTP  http://localhost:8080  not ready  5         10s   the status code doesn't expect", lines[2])
+	assert.Equal(t, "TCP   127.0.0.1:5432         canceled   1         1s    -", lines[3])
+}
@@ -0,0 +1,98 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing provides the OpenTelemetry trace export of the waiting, configured
+// by the standard OTEL_* environment variables.
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ServiceName is the default service name of the exported spans, it can be changed
+// with OTEL_SERVICE_NAME or OTEL_RESOURCE_ATTRIBUTES.
+const ServiceName = "wait4x"
+
+// Enabled reports whether the trace export is configured by the environment variables.
+// The export is enabled when an OTLP endpoint is set or OTEL_TRACES_EXPORTER is otlp,
+// unless OTEL_SDK_DISABLED is true or OTEL_TRACES_EXPORTER is something else, e.g. none.
+func Enabled() bool {
+	return enabled(os.Getenv)
+}
+
+func enabled(getenv func(string) string) bool {
+	if strings.EqualFold(getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+
+	switch exporter := getenv("OTEL_TRACES_EXPORTER"); exporter {
+	case "otlp":
+		return true
+	case "":
+		return getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+	default:
+		return false
+	}
+}
+
+// NewTracerProvider creates a tracer provider exporting the spans over OTLP/HTTP. The exporter,
+// the sampler and the resource are configured by the standard environment variables, e.g.
+// OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS and OTEL_TRACES_SAMPLER.
+// The tracer provider must be shut down to flush the spans.
+func NewTracerProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The attributes of the environment take precedence over the default service name
+	res, err := resource.New(
+		ctx,
+		resource.WithAttributes(attribute.String("service.name", ServiceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	), nil
+}
+
+// ContextWithParent returns a context with the remote parent span of the TRACEPARENT and
+// TRACESTATE environment variables, so the spans join the trace of the calling process,
+// e.g. a deployment pipeline. The context is returned as is when TRACEPARENT isn't set.
+func ContextWithParent(ctx context.Context) context.Context {
+	return contextWithParent(ctx, os.Getenv)
+}
+
+func contextWithParent(ctx context.Context, getenv func(string) string) context.Context {
+	carrier := propagation.MapCarrier{
+		"traceparent": getenv("TRACEPARENT"),
+		"tracestate":  getenv("TRACESTATE"),
+	}
+
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}
@@ -0,0 +1,55 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		expected bool
+	}{
+		{env: map[string]string{}, expected: false},
+		{env: map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}, expected: true},
+		{env: map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://localhost:4318/v1/traces"}, expected: true},
+		{env: map[string]string{"OTEL_TRACES_EXPORTER": "otlp"}, expected: true},
+		{env: map[string]string{"OTEL_TRACES_EXPORTER": "none", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}, expected: false},
+		{env: map[string]string{"OTEL_SDK_DISABLED": "true", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		getenv := func(key string) string { return tt.env[key] }
+		assert.Equal(t, tt.expected, enabled(getenv), "%v", tt.env)
+	}
+}
+
+func TestContextWithParent(t *testing.T) {
+	env := map[string]string{"TRACEPARENT": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	ctx := contextWithParent(context.Background(), func(key string) string { return env[key] })
+
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", sc.SpanID().String())
+
+	ctx = contextWithParent(context.Background(), func(string) string { return "" })
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
@@ -0,0 +1,211 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff is the interface that calculates the waiting time between checks.
+//
+// Next receives the zero based retry number and the previously returned duration
+// (zero for the first retry) and returns the duration to wait before the next check.
+// Implementations must be safe for concurrent use, because the same Backoff is
+// shared between all checkers of a parallel wait.
+type Backoff interface {
+	Next(retries int, previous time.Duration) time.Duration
+}
+
+// BackoffFunc is an adapter to allow the use of ordinary functions as Backoff.
+type BackoffFunc func(retries int, previous time.Duration) time.Duration
+
+// Next calls f(retries, previous).
+func (f BackoffFunc) Next(retries int, previous time.Duration) time.Duration {
+	return f(retries, previous)
+}
+
+// linearBackoff waits a constant interval between checks.
+type linearBackoff struct {
+	interval time.Duration
+}
+
+// NewLinearBackoff creates a Backoff which always waits the given interval.
+func NewLinearBackoff(interval time.Duration) Backoff {
+	return &linearBackoff{interval: interval}
+}
+
+// Next returns the constant interval.
+func (lb *linearBackoff) Next(int, time.Duration) time.Duration {
+	return lb.interval
+}
+
+// exponentialBackoff multiplies the interval by the coefficient on each retry.
+type exponentialBackoff struct {
+	interval    time.Duration
+	coefficient float64
+	maxInterval time.Duration
+}
+
+// NewExponentialBackoff creates a Backoff which waits interval * coefficient^retries,
+// capped at maxInterval.
+func NewExponentialBackoff(interval time.Duration, coefficient float64, maxInterval time.Duration) Backoff {
+	return &exponentialBackoff{
+		interval:    interval,
+		coefficient: coefficient,
+		maxInterval: maxInterval,
+	}
+}
+
+// Next returns the exponentially growing interval.
+func (eb *exponentialBackoff) Next(retries int, _ time.Duration) time.Duration {
+	interval := float64(eb.interval) * math.Pow(eb.coefficient, float64(retries))
+	if interval > float64(eb.maxInterval) {
+		return eb.maxInterval
+	}
+
+	return time.Duration(interval)
+}
+
+// fibonacciBackoff grows the interval following the Fibonacci sequence.
+type fibonacciBackoff struct {
+	interval    time.Duration
+	maxInterval time.Duration
+}
+
+// NewFibonacciBackoff creates a Backoff which waits interval * fib(retries+1)
+// (1, 1, 2, 3, 5, ... times the interval), capped at maxInterval.
+func NewFibonacciBackoff(interval, maxInterval time.Duration) Backoff {
+	return &fibonacciBackoff{
+		interval:    interval,
+		maxInterval: maxInterval,
+	}
+}
+
+// Next returns the Fibonacci interval.
+func (fb *fibonacciBackoff) Next(retries int, _ time.Duration) time.Duration {
+	prev, curr := time.Duration(0), fb.interval
+	for i := 0; i < retries; i++ {
+		prev, curr = curr, prev+curr
+		if curr >= fb.maxInterval {
+			return fb.maxInterval
+		}
+	}
+
+	return min(curr, fb.maxInterval)
+}
+
+// fullJitterBackoff randomizes the whole interval of the wrapped Backoff.
+type fullJitterBackoff struct {
+	backoff Backoff
+}
+
+// NewFullJitterBackoff creates a Backoff which waits a random duration
+// between zero and the interval returned by the given Backoff.
+func NewFullJitterBackoff(backoff Backoff) Backoff {
+	return &fullJitterBackoff{backoff: backoff}
+}
+
+// Next returns the fully jittered interval.
+func (fj *fullJitterBackoff) Next(retries int, previous time.Duration) time.Duration {
+	return randomDuration(0, fj.backoff.Next(retries, previous))
+}
+
+// equalJitterBackoff keeps half of the wrapped interval and randomizes the other half.
+type equalJitterBackoff struct {
+	backoff Backoff
+}
+
+// NewEqualJitterBackoff creates a Backoff which waits half of the interval returned by
+// the given Backoff plus a random duration between zero and the other half.
+func NewEqualJitterBackoff(backoff Backoff) Backoff {
+	return &equalJitterBackoff{backoff: backoff}
+}
+
+// Next returns the equally jittered interval.
+func (ej *equalJitterBackoff) Next(retries int, previous time.Duration) time.Duration {
+	half := ej.backoff.Next(retries, previous) / 2
+
+	return half + randomDuration(0, half)
+}
+
+// decorrelatedJitterBackoff derives each interval from the previous one.
+type decorrelatedJitterBackoff struct {
+	interval    time.Duration
+	maxInterval time.Duration
+}
+
+// NewDecorrelatedJitterBackoff creates a Backoff which waits a random duration between
+// interval and three times the previous wait, capped at maxInterval.
+func NewDecorrelatedJitterBackoff(interval, maxInterval time.Duration) Backoff {
+	return &decorrelatedJitterBackoff{
+		interval:    interval,
+		maxInterval: maxInterval,
+	}
+}
+
+// Next returns the decorrelated jittered interval.
+func (dj *decorrelatedJitterBackoff) Next(_ int, previous time.Duration) time.Duration {
+	previous = max(previous, dj.interval)
+
+	return min(randomDuration(dj.interval, previous*3), dj.maxInterval)
+}
+
+// randomDuration returns a random duration in the closed interval [lower, upper].
+func randomDuration(lower, upper time.Duration) time.Duration {
+	if upper <= lower {
+		return lower
+	}
+
+	return lower + rand.N(upper-lower+1)
+}
+
+// newBackoff builds the Backoff described by the backoff policy and jitter options.
+func newBackoff(o *options) (Backoff, error) {
+	if o.backoff != nil {
+		return o.backoff, nil
+	}
+
+	var backoff Backoff
+	switch o.backoffPolicy {
+	case BackoffPolicyLinear:
+		backoff = NewLinearBackoff(o.interval)
+	case BackoffPolicyExponential:
+		backoff = NewExponentialBackoff(o.interval, o.backoffCoefficient, o.backoffExponentialMaxInterval)
+	case BackoffPolicyFibonacci:
+		backoff = NewFibonacciBackoff(o.interval, o.backoffExponentialMaxInterval)
+	case BackoffPolicyDecorrelatedJitter:
+		if o.backoffJitter != BackoffJitterNone {
+			return nil, fmt.Errorf("backoff jitter %s can't be combined with the %s backoff policy", o.backoffJitter, o.backoffPolicy)
+		}
+
+		return NewDecorrelatedJitterBackoff(o.interval, o.backoffExponentialMaxInterval), nil
+	default:
+		return nil, fmt.Errorf("invalid backoff policy: %s", o.backoffPolicy)
+	}
+
+	switch o.backoffJitter {
+	case BackoffJitterNone:
+		return backoff, nil
+	case BackoffJitterFull:
+		return NewFullJitterBackoff(backoff), nil
+	case BackoffJitterEqual:
+		return NewEqualJitterBackoff(backoff), nil
+	default:
+		return nil, fmt.Errorf("invalid backoff jitter: %s", o.backoffJitter)
+	}
+}
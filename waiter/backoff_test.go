@@ -0,0 +1,115 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wait4x.dev/v3/checker"
+)
+
+func TestLinearBackoff(t *testing.T) {
+	b := NewLinearBackoff(time.Second)
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, time.Second, b.Next(i, 0))
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := NewExponentialBackoff(time.Second, 2, 10*time.Second)
+
+	assert.Equal(t, time.Second, b.Next(0, 0))
+	assert.Equal(t, 2*time.Second, b.Next(1, 0))
+	assert.Equal(t, 8*time.Second, b.Next(3, 0))
+	assert.Equal(t, 10*time.Second, b.Next(4, 0))
+	assert.Equal(t, 10*time.Second, b.Next(1000, 0))
+}
+
+func TestFibonacciBackoff(t *testing.T) {
+	b := NewFibonacciBackoff(time.Second, 10*time.Second)
+
+	expected := []time.Duration{1, 1, 2, 3, 5, 8, 10, 10}
+	for i, e := range expected {
+		assert.Equal(t, e*time.Second, b.Next(i, 0))
+	}
+}
+
+func TestFullJitterBackoff(t *testing.T) {
+	b := NewFullJitterBackoff(NewLinearBackoff(time.Second))
+
+	for i := 0; i < 100; i++ {
+		d := b.Next(i, 0)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, time.Second)
+	}
+}
+
+func TestEqualJitterBackoff(t *testing.T) {
+	b := NewEqualJitterBackoff(NewLinearBackoff(time.Second))
+
+	for i := 0; i < 100; i++ {
+		d := b.Next(i, 0)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.LessOrEqual(t, d, time.Second)
+	}
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	b := NewDecorrelatedJitterBackoff(time.Second, 5*time.Second)
+
+	var previous time.Duration
+	for i := 0; i < 100; i++ {
+		d := b.Next(i, previous)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, max(previous, time.Second)*3)
+		assert.LessOrEqual(t, d, 5*time.Second)
+		previous = d
+	}
+}
+
+func TestWaitCustomBackoff(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(assert.AnError).Twice().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	var calls []int
+	backoff := BackoffFunc(func(retries int, _ time.Duration) time.Duration {
+		calls = append(calls, retries)
+		return time.Millisecond
+	})
+
+	err := Wait(mockChecker, WithTimeout(time.Second), WithBackoff(backoff))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1}, calls)
+	mockChecker.AssertExpectations(t)
+}
+
+func TestWaitInvalidBackoff(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+
+	err := Wait(mockChecker, WithBackoffPolicy("unknown"))
+	assert.EqualError(t, err, "invalid backoff policy: unknown")
+
+	err = Wait(mockChecker, WithBackoffJitter("unknown"))
+	assert.EqualError(t, err, "invalid backoff jitter: unknown")
+
+	err = Wait(mockChecker, WithBackoffPolicy(BackoffPolicyDecorrelatedJitter), WithBackoffJitter(BackoffJitterFull))
+	assert.EqualError(t, err, "backoff jitter full can't be combined with the decorrelated-jitter backoff policy")
+}
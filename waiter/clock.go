@@ -0,0 +1,207 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and measures the durations of the waiting, see WithClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a Timer that sends the current time on its channel after the duration.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event created by a Clock, like time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+	// Stop prevents the Timer from firing, it reports whether the timer was stopped before it fired.
+	Stop() bool
+}
+
+// WithClock configures the clock of the timeout, the deadline, the delays and the intervals of the
+// waiting. The system clock is used by default, a fake clock like waitertest.Clock makes the tests
+// of the waiting instant and deterministic.
+//
+// With another clock than the system clock, the contexts passed to the checkers don't report the
+// timeout and the deadline of the waiting through their Deadline method, the checkers still see them
+// through Done and Err.
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// realClock is the Clock of the system time.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{timer: time.NewTimer(d)}
+}
+
+// realTimer is the Timer of the system time.
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// since returns the time elapsed since t by the clock.
+func (o *options) since(t time.Time) time.Duration {
+	return o.clock.Now().Sub(t)
+}
+
+// sleep waits for the duration by the clock, it returns the context error when the context
+// is done first.
+func (o *options) sleep(ctx context.Context, d time.Duration) error {
+	timer := o.clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
+
+// withTimeLimit limits the context by the timeout and the deadline options, the earliest of them applies.
+func (o *options) withTimeLimit(ctx context.Context) (context.Context, context.CancelFunc) {
+	cancels := make([]context.CancelFunc, 0, 2)
+
+	// Ignore timeout context when the timeout is unlimited
+	if o.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = o.withTimeout(ctx, o.timeout)
+		cancels = append(cancels, cancel)
+	}
+
+	if !o.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = o.withDeadline(ctx, o.deadline)
+		cancels = append(cancels, cancel)
+	}
+
+	return ctx, func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
+// withTimeout is context.WithTimeout measured by the clock.
+func (o *options) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return o.withDeadline(ctx, o.clock.Now().Add(timeout))
+}
+
+// withDeadline is context.WithDeadline measured by the clock.
+func (o *options) withDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	if _, ok := o.clock.(realClock); ok {
+		return context.WithDeadline(ctx, deadline)
+	}
+
+	c := &clockContext{
+		Context:  ctx,
+		deadline: deadline,
+		done:     make(chan struct{}),
+	}
+
+	timer := o.clock.NewTimer(deadline.Sub(o.clock.Now()))
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.cancel(ctx.Err())
+		case <-timer.C():
+			c.cancel(context.DeadlineExceeded)
+		case <-c.done:
+		}
+
+		timer.Stop()
+	}()
+
+	return c, func() {
+		// The timer is stopped at once, so a fake clock doesn't wait for it anymore
+		timer.Stop()
+		c.cancel(context.Canceled)
+	}
+}
+
+// clockContext is a context with a deadline measured by a Clock other than the system clock.
+//
+// Its Deadline method reports the deadline of the parent context, not its own one. The own deadline
+// is a time of the other clock, while the checkers and the libraries deriving network timeouts from
+// the deadline compare it with the system time. The own deadline only ends the context through Done.
+type clockContext struct {
+	context.Context
+	deadline time.Time
+	done     chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+func (c *clockContext) Deadline() (time.Time, bool) {
+	return c.Context.Deadline()
+}
+
+func (c *clockContext) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *clockContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+func (c *clockContext) String() string {
+	return fmt.Sprintf("%v.WithDeadline(%s)", c.Context, c.deadline)
+}
+
+// cancel closes the done channel with the error, unless the context is already done.
+func (c *clockContext) cancel(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return
+	}
+
+	c.err = err
+	close(c.done)
+}
@@ -0,0 +1,157 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"wait4x.dev/v3/checker"
+)
+
+// ErrPermanent is returned when a check failed with an error which retrying can't resolve,
+// see checker.IsPermanentError.
+var ErrPermanent = errors.New("the check failed permanently")
+
+// CheckerError attributes an error to the checker that caused it
+type CheckerError struct {
+	// Checker is the failed checker.
+	Checker checker.Checker
+	// Identity is the identity of the failed checker, empty when it can't be retrieved.
+	Identity string
+	// Name is the name of the failed checker, empty when it doesn't implement checker.Named.
+	Name string
+	// Err is the error returned by waiting for the checker.
+	Err error
+}
+
+// newCheckerError creates the CheckerError
+func newCheckerError(chk checker.Checker, err error) error {
+	id, _ := chk.Identity()
+
+	ce := &CheckerError{
+		Checker:  chk,
+		Identity: id,
+		Err:      err,
+	}
+	if named, ok := chk.(checker.Named); ok {
+		ce.Name = named.Name()
+	}
+
+	return ce
+}
+
+func (ce *CheckerError) Unwrap() error {
+	return ce.Err
+}
+
+func (ce *CheckerError) Error() string {
+	switch {
+	case ce.Name != "" && ce.Identity != "":
+		return fmt.Sprintf("%s (%s): %s", ce.Name, ce.Identity, ce.Err.Error())
+	case ce.Name != "":
+		return fmt.Sprintf("%s: %s", ce.Name, ce.Err.Error())
+	case ce.Identity != "":
+		return fmt.Sprintf("%s: %s", ce.Identity, ce.Err.Error())
+	default:
+		return ce.Err.Error()
+	}
+}
+
+// maxFailureHistory is the maximum number of distinct failures kept by a TimeoutError.
+const maxFailureHistory = 5
+
+// Failure is a distinct failure of the check attempts, the failures are told apart by their messages.
+type Failure struct {
+	// Err is the error of the last attempt which failed with the message.
+	Err error
+	// Count is the number of attempts which failed with the message.
+	Count int
+}
+
+// TimeoutError is returned when the time ran out before the checker was ready. It wraps
+// context.DeadlineExceeded and the error of the last check attempt.
+type TimeoutError struct {
+	// LastErr is the error of the last failed check attempt, nil when there was no attempt or
+	// the check kept passing in the invert mode.
+	LastErr error
+	// Attempts is the number of check attempts.
+	Attempts int
+	// History is the distinct failures of the check attempts in the order they first occurred,
+	// only the first few of them are kept.
+	History []Failure
+}
+
+// Unwrap returns context.DeadlineExceeded followed by the error of the last check attempt, so the
+// timeout takes precedence when the error is matched with errors.Is in that order.
+func (te *TimeoutError) Unwrap() []error {
+	if te.LastErr == nil {
+		return []error{context.DeadlineExceeded}
+	}
+
+	return []error{context.DeadlineExceeded, te.LastErr}
+}
+
+func (te *TimeoutError) Error() string {
+	switch {
+	case te.Attempts == 0:
+		return context.DeadlineExceeded.Error()
+	case te.LastErr == nil:
+		return fmt.Sprintf("%s after %s", context.DeadlineExceeded, te.attempts())
+	default:
+		return fmt.Sprintf("%s after %s, last error: %s", context.DeadlineExceeded, te.attempts(), te.LastErr)
+	}
+}
+
+func (te *TimeoutError) attempts() string {
+	if te.Attempts == 1 {
+		return "1 attempt"
+	}
+
+	return fmt.Sprintf("%d attempts", te.Attempts)
+}
+
+// failureHistory records the distinct failures of the check attempts.
+type failureHistory []Failure
+
+// add records the error of a failed check attempt.
+func (h *failureHistory) add(err error) {
+	for i := range *h {
+		if (*h)[i].Err.Error() == err.Error() {
+			(*h)[i].Err = err
+			(*h)[i].Count++
+			return
+		}
+	}
+
+	if len(*h) < maxFailureHistory {
+		*h = append(*h, Failure{Err: err, Count: 1})
+	}
+}
+
+// newTimeoutError returns a TimeoutError when the context error tells the time ran out,
+// otherwise the context error as is.
+func newTimeoutError(ctxErr error, attempts int, lastErr error, history failureHistory) error {
+	if !errors.Is(ctxErr, context.DeadlineExceeded) {
+		return ctxErr
+	}
+
+	return &TimeoutError{
+		LastErr:  lastErr,
+		Attempts: attempts,
+		History:  history,
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"wait4x.dev/v3/checker"
+)
+
+// ErrDependencyNotReady is returned for the nodes that weren't checked because one of
+// their dependencies didn't become ready.
+var ErrDependencyNotReady = errors.New("dependency isn't ready")
+
+// Node is a named checker of a dependency graph.
+type Node struct {
+	// Name is the unique name of the node.
+	Name string
+	// Checker is the checker of the node.
+	Checker checker.Checker
+	// DependsOn is the list of node names which must be ready before checking the node.
+	DependsOn []string
+}
+
+// WaitGraph waits for end up all of the graph nodes execution.
+func WaitGraph(nodes []Node, opts ...Option) error {
+	return WaitGraphContext(context.Background(), nodes, opts...)
+}
+
+// WaitGraphContext waits for end up all of the graph nodes execution. Each node is checked
+// only after all of its dependencies are ready, and independent nodes are checked concurrently.
+// The timeout and the deadline options apply to the whole graph.
+//
+// The graph is validated before checking, unknown dependencies and cycles are reported as errors.
+// When some nodes fail, the returned error joins a CheckerError for each of them, the nodes
+// depending on a failed node fail with ErrDependencyNotReady.
+func WaitGraphContext(ctx context.Context, nodes []Node, opts ...Option) error {
+	index, err := validateGraph(nodes)
+	if err != nil {
+		return err
+	}
+
+	options := newOptions(opts...)
+	opts, err = withSharedLimiter(opts, options)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancelTimeLimit := options.withTimeLimit(ctx)
+	defer cancelTimeLimit()
+
+	// The nodes don't restart the time limit when they start to be checked
+	opts = withOptions(opts, WithTimeout(0), WithDeadline(time.Time{}))
+
+	groupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Every goroutine writes its own error slot before closing its done channel
+	errs := make([]error, len(nodes))
+	done := make([]chan struct{}, len(nodes))
+	for i := range nodes {
+		done[i] = make(chan struct{})
+	}
+
+	// failed reports whether the remaining nodes were canceled by the fail fast mode
+	var failed atomic.Bool
+
+	var wg sync.WaitGroup
+
+	for i, node := range nodes {
+		wg.Add(1)
+
+		go func(i int, node Node) {
+			defer wg.Done()
+			defer close(done[i])
+
+			err := waitNode(groupCtx, node, index, errs, done, withOptions(opts, withIndex(i)))
+			if err == nil {
+				return
+			}
+
+			// Ignore the nodes canceled on purpose
+			if errors.Is(err, context.Canceled) && failed.Load() && ctx.Err() == nil {
+				return
+			}
+
+			errs[i] = newCheckerError(node.Checker, err)
+
+			if options.failFast {
+				failed.Store(true)
+				cancel()
+			}
+		}(i, node)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// waitNode waits for the dependencies of the node and then for the node itself.
+func waitNode(ctx context.Context, node Node, index map[string]int, errs []error, done []chan struct{}, opts []Option) error {
+	for _, dep := range node.DependsOn {
+		// The dependency ends as soon as the context is done too, so its failure is reported
+		// instead of the context error
+		<-done[index[dep]]
+
+		if errs[index[dep]] != nil {
+			return fmt.Errorf("%w: %s", ErrDependencyNotReady, dep)
+		}
+	}
+
+	// A dependency canceled on purpose, e.g. by the fail fast mode, leaves no error behind
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return WaitContext(ctx, node.Checker, opts...)
+}
+
+// validateGraph validates the graph nodes and returns the index of each node by name.
+func validateGraph(nodes []Node) (map[string]int, error) {
+	index := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		if node.Name == "" {
+			return nil, fmt.Errorf("the node %d has no name", i)
+		}
+
+		if node.Checker == nil {
+			return nil, fmt.Errorf("the node %s has no checker", node.Name)
+		}
+
+		if _, ok := index[node.Name]; ok {
+			return nil, fmt.Errorf("duplicate node: %s", node.Name)
+		}
+
+		index[node.Name] = i
+	}
+
+	for _, node := range nodes {
+		for _, dep := range node.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("the node %s depends on the unknown node %s", node.Name, dep)
+			}
+		}
+	}
+
+	// Depth-first search, a node on the current path which is visited again closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := make([]int, len(nodes))
+	var path []string
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch states[i] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for path[start] != nodes[i].Name {
+				start++
+			}
+
+			return fmt.Errorf("dependency cycle detected: %s -> %s", strings.Join(path[start:], " -> "), nodes[i].Name)
+		}
+
+		states[i] = visiting
+		path = append(path, nodes[i].Name)
+
+		for _, dep := range nodes[i].DependsOn {
+			if err := visit(index[dep]); err != nil {
+				return err
+			}
+		}
+
+		states[i] = visited
+		path = path[:len(path)-1]
+
+		return nil
+	}
+
+	for i := range nodes {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return index, nil
+}
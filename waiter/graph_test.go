@@ -0,0 +1,185 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wait4x.dev/v3/checker"
+)
+
+// orderRecorder records the order of the successful checks.
+type orderRecorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (or *orderRecorder) checker(name string) checker.Checker {
+	chk := new(checker.MockChecker)
+	chk.On("Check", mock.Anything).Run(func(mock.Arguments) {
+		or.mu.Lock()
+		defer or.mu.Unlock()
+		or.order = append(or.order, name)
+	}).Return(nil).
+		On("Identity").Return(name, nil)
+
+	return chk
+}
+
+func TestWaitGraph(t *testing.T) {
+	recorder := new(orderRecorder)
+
+	err := WaitGraph([]Node{
+		{Name: "api", Checker: recorder.checker("api"), DependsOn: []string{"migration", "cache"}},
+		{Name: "migration", Checker: recorder.checker("migration"), DependsOn: []string{"db"}},
+		{Name: "db", Checker: recorder.checker("db")},
+		{Name: "cache", Checker: recorder.checker("cache")},
+	})
+	assert.Nil(t, err)
+
+	assert.Len(t, recorder.order, 4)
+	position := make(map[string]int)
+	for i, name := range recorder.order {
+		position[name] = i
+	}
+	assert.Less(t, position["db"], position["migration"])
+	assert.Less(t, position["migration"], position["api"])
+	assert.Less(t, position["cache"], position["api"])
+}
+
+func TestWaitGraphDependencyFailed(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	db := new(checker.MockChecker)
+	db.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("db", nil)
+
+	api := new(checker.MockChecker)
+	api.On("Identity").Return("api", nil)
+
+	err := WaitGraph([]Node{
+		{Name: "api", Checker: api, DependsOn: []string{"db"}},
+		{Name: "db", Checker: db},
+	}, WithTimeout(100*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, ErrDependencyNotReady)
+	assert.Equal(t, "api: dependency isn't ready: db\ndb: context deadline exceeded after 1 attempt, last error: error", err.Error())
+	api.AssertNotCalled(t, "Check", mock.Anything)
+
+	assertNoGoroutineLeak(t, baseline)
+}
+
+func TestWaitGraphTimeout(t *testing.T) {
+	db := new(checker.MockChecker)
+	db.On("Check", mock.Anything).Return(fmt.Errorf("error")).Twice().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("db", nil)
+
+	api := new(checker.MockChecker)
+	api.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("api", nil)
+
+	// The timeout applies to the whole graph, it doesn't restart when the api starts to be checked
+	start := time.Now()
+	err := WaitGraph([]Node{
+		{Name: "api", Checker: api, DependsOn: []string{"db"}},
+		{Name: "db", Checker: db},
+	}, WithTimeout(200*time.Millisecond), WithInterval(50*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Regexp(t, `^api: context deadline exceeded after \d+ attempts?, last error: error$`, err.Error())
+	assert.Less(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestWaitGraphFailFast(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	malformed := new(checker.MockChecker)
+	malformed.On("Check", mock.Anything).Return(checker.NewPermanentError(fmt.Errorf("invalid DSN"))).
+		On("Identity").Return("db", nil)
+
+	slow := new(checker.MockChecker)
+	slow.On("Check", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.Canceled).
+		On("Identity").Return("cache", nil)
+
+	api := new(checker.MockChecker)
+	api.On("Identity").Return("api", nil)
+
+	// The dependency canceled on purpose doesn't let the api be checked
+	err := WaitGraph([]Node{
+		{Name: "db", Checker: malformed},
+		{Name: "cache", Checker: slow},
+		{Name: "api", Checker: api, DependsOn: []string{"cache"}},
+	}, WithTimeout(time.Minute), WithFailFast(true))
+	assert.ErrorIs(t, err, ErrPermanent)
+	assert.EqualError(t, err, "db: the check failed permanently: invalid DSN")
+	api.AssertNotCalled(t, "Check", mock.Anything)
+
+	assertNoGoroutineLeak(t, baseline)
+}
+
+func TestWaitGraphInvalid(t *testing.T) {
+	chk := new(checker.MockChecker)
+
+	tests := []struct {
+		name  string
+		nodes []Node
+		err   string
+	}{
+		{
+			name:  "unnamed",
+			nodes: []Node{{Checker: chk}},
+			err:   "the node 0 has no name",
+		},
+		{
+			name:  "duplicate",
+			nodes: []Node{{Name: "a", Checker: chk}, {Name: "a", Checker: chk}},
+			err:   "duplicate node: a",
+		},
+		{
+			name:  "unknown dependency",
+			nodes: []Node{{Name: "a", Checker: chk, DependsOn: []string{"b"}}},
+			err:   "the node a depends on the unknown node b",
+		},
+		{
+			name: "cycle",
+			nodes: []Node{
+				{Name: "a", Checker: chk, DependsOn: []string{"b"}},
+				{Name: "b", Checker: chk, DependsOn: []string{"c"}},
+				{Name: "c", Checker: chk, DependsOn: []string{"a"}},
+			},
+			err: "dependency cycle detected: a -> b -> c -> a",
+		},
+		{
+			name:  "self dependency",
+			nodes: []Node{{Name: "a", Checker: chk, DependsOn: []string{"a"}}},
+			err:   "dependency cycle detected: a -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, WaitGraph(tt.nodes), tt.err)
+		})
+	}
+}
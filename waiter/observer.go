@@ -0,0 +1,130 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"time"
+
+	"wait4x.dev/v3/checker"
+)
+
+// Attempt describes a single check attempt.
+type Attempt struct {
+	// Number is the one based attempt number.
+	Number int
+	// Duration is the time the check took.
+	Duration time.Duration
+	// Err is the error returned by the check, nil when the check passed.
+	Err error
+}
+
+// Observer is the interface that is notified about the lifecycle of waiting for a checker.
+//
+// The same Observer receives the events of all checkers of a parallel wait from different
+// goroutines, so implementations must be safe for concurrent use.
+type Observer interface {
+	// OnStart is called once before the first check attempt.
+	OnStart(chk checker.Checker)
+	// OnAttempt is called after each check attempt.
+	OnAttempt(chk checker.Checker, attempt Attempt)
+	// OnReady is called once the checker reached the expected state.
+	OnReady(chk checker.Checker, attempts int, elapsed time.Duration)
+	// OnGiveUp is called once the waiting stopped without reaching the expected state.
+	OnGiveUp(chk checker.Checker, attempts int, elapsed time.Duration, err error)
+}
+
+// TransitionObserver is an optional interface of an Observer which is notified about the state
+// transitions of a watched checker, see Watch.
+type TransitionObserver interface {
+	// OnTransition is called each time the state of a watched checker changes.
+	OnTransition(chk checker.Checker, transition Transition)
+}
+
+// WaitingObserver is an optional interface of an Observer which observes each waiting with an
+// Observer of its own, instead of telling the waitings apart by their checkers. The checkers
+// may not be comparable, and the same checker may be waited for more than once.
+type WaitingObserver interface {
+	Observer
+	// NewWaiting is called before waiting for a checker starts and returns the Observer which
+	// receives the events of the waiting. The index is the position of the checker in the
+	// checkers or the nodes of the waiting, 0 when waiting for a single checker.
+	NewWaiting(chk checker.Checker, index int) Observer
+}
+
+// NopObserver is an Observer that does nothing. It can be embedded to implement
+// only some of the Observer methods.
+type NopObserver struct{}
+
+// OnStart does nothing.
+func (NopObserver) OnStart(checker.Checker) {}
+
+// OnAttempt does nothing.
+func (NopObserver) OnAttempt(checker.Checker, Attempt) {}
+
+// OnReady does nothing.
+func (NopObserver) OnReady(checker.Checker, int, time.Duration) {}
+
+// OnGiveUp does nothing.
+func (NopObserver) OnGiveUp(checker.Checker, int, time.Duration, error) {}
+
+// observers fans out the events to a list of observers.
+type observers []Observer
+
+func (obs observers) OnStart(chk checker.Checker) {
+	for _, o := range obs {
+		o.OnStart(chk)
+	}
+}
+
+func (obs observers) OnAttempt(chk checker.Checker, attempt Attempt) {
+	for _, o := range obs {
+		o.OnAttempt(chk, attempt)
+	}
+}
+
+func (obs observers) OnReady(chk checker.Checker, attempts int, elapsed time.Duration) {
+	for _, o := range obs {
+		o.OnReady(chk, attempts, elapsed)
+	}
+}
+
+func (obs observers) OnGiveUp(chk checker.Checker, attempts int, elapsed time.Duration, err error) {
+	for _, o := range obs {
+		o.OnGiveUp(chk, attempts, elapsed, err)
+	}
+}
+
+func (obs observers) OnTransition(chk checker.Checker, transition Transition) {
+	for _, o := range obs {
+		if to, ok := o.(TransitionObserver); ok {
+			to.OnTransition(chk, transition)
+		}
+	}
+}
+
+// forWaiting returns the observers of a single waiting, the WaitingObservers are replaced with
+// the Observers they return for the waiting.
+func (obs observers) forWaiting(chk checker.Checker, index int) observers {
+	scoped := make(observers, len(obs))
+	for i, o := range obs {
+		if wo, ok := o.(WaitingObserver); ok {
+			o = wo.NewWaiting(chk, index)
+		}
+
+		scoped[i] = o
+	}
+
+	return scoped
+}
@@ -0,0 +1,226 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"wait4x.dev/v3/checker"
+)
+
+// Result describes the outcome of waiting for a checker.
+type Result struct {
+	// Checker is the checker the result belongs to.
+	Checker checker.Checker
+	// Identity is the identity of the checker.
+	Identity string
+	// Name is the name of the checker, see checker.Named. It's the type name of the checker
+	// when it doesn't implement checker.Named.
+	Name string
+	// Labels are the labels of the checker, see checker.Named.
+	Labels map[string]string
+	// Ready reports whether the checker reached the expected state.
+	Ready bool
+	// Canceled reports whether the waiting was canceled before the checker reached the expected
+	// state, e.g. the remaining checkers once enough of them are ready in the WaitAny mode.
+	Canceled bool
+	// Attempts is the number of check attempts.
+	Attempts int
+	// TimeToReady is the time it took to reach the expected state, zero when not ready.
+	TimeToReady time.Duration
+	// Elapsed is the whole waiting time.
+	Elapsed time.Duration
+	// LastError is the last error returned by the checker, nil when the checker never failed.
+	LastError error
+	// History contains all the check attempts in order.
+	History []Attempt
+}
+
+// resultRecorder is an Observer which builds the Result of a single checker.
+type resultRecorder struct {
+	result  *Result
+	started bool
+}
+
+func newResultRecorder(chk checker.Checker) *resultRecorder {
+	return &resultRecorder{result: &Result{Checker: chk}}
+}
+
+func (rr *resultRecorder) OnStart(chk checker.Checker) {
+	rr.started = true
+	rr.result.Identity, _ = chk.Identity()
+	rr.result.Name = CheckerName(chk)
+	if named, ok := chk.(checker.Named); ok {
+		rr.result.Labels = named.Labels()
+	}
+}
+
+func (rr *resultRecorder) OnAttempt(_ checker.Checker, attempt Attempt) {
+	rr.result.Attempts = attempt.Number
+	rr.result.History = append(rr.result.History, attempt)
+	if attempt.Err != nil {
+		rr.result.LastError = attempt.Err
+	}
+}
+
+func (rr *resultRecorder) OnReady(_ checker.Checker, _ int, elapsed time.Duration) {
+	rr.result.Ready = true
+	rr.result.TimeToReady = elapsed
+	rr.result.Elapsed = elapsed
+}
+
+func (rr *resultRecorder) OnGiveUp(_ checker.Checker, _ int, elapsed time.Duration, err error) {
+	rr.result.Elapsed = elapsed
+	rr.result.Canceled = errors.Is(err, context.Canceled)
+}
+
+// ResultCollector is a WaitingObserver which collects the Result of each observed waiting.
+// It's safe for concurrent use, so it can observe a parallel waiting.
+type ResultCollector struct {
+	NopObserver
+
+	mu        sync.Mutex
+	recorders []indexedRecorder
+}
+
+// indexedRecorder is the resultRecorder of a waiting and the position of its checker.
+type indexedRecorder struct {
+	index    int
+	recorder *resultRecorder
+}
+
+// NewResultCollector creates the ResultCollector
+func NewResultCollector() *ResultCollector {
+	return &ResultCollector{}
+}
+
+// NewWaiting starts collecting the result of a waiting.
+func (rc *ResultCollector) NewWaiting(chk checker.Checker, index int) Observer {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rr := newResultRecorder(chk)
+	rc.recorders = append(rc.recorders, indexedRecorder{index: index, recorder: rr})
+
+	return &syncObserver{mu: &rc.mu, observer: rr}
+}
+
+// Results returns a copy of the collected results in the order of the checkers, the results of
+// the same position, e.g. of the sequential waitings, are in the order the waitings started.
+// The waitings which failed before they started, e.g. because of an invalid identity, have no result.
+func (rc *ResultCollector) Results() []Result {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	recorders := append([]indexedRecorder(nil), rc.recorders...)
+	sort.SliceStable(recorders, func(i, j int) bool {
+		return recorders[i].index < recorders[j].index
+	})
+
+	results := make([]Result, 0, len(recorders))
+	for _, ir := range recorders {
+		if !ir.recorder.started {
+			continue
+		}
+
+		result := *ir.recorder.result
+		result.History = append([]Attempt(nil), result.History...)
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// syncObserver serializes the events of an Observer with a mutex shared with its owner.
+type syncObserver struct {
+	mu       *sync.Mutex
+	observer Observer
+}
+
+func (so *syncObserver) OnStart(chk checker.Checker) {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	so.observer.OnStart(chk)
+}
+
+func (so *syncObserver) OnAttempt(chk checker.Checker, attempt Attempt) {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	so.observer.OnAttempt(chk, attempt)
+}
+
+func (so *syncObserver) OnReady(chk checker.Checker, attempts int, elapsed time.Duration) {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	so.observer.OnReady(chk, attempts, elapsed)
+}
+
+func (so *syncObserver) OnGiveUp(chk checker.Checker, attempts int, elapsed time.Duration, err error) {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	so.observer.OnGiveUp(chk, attempts, elapsed, err)
+}
+
+// WaitWithResult waits for end up of check execution and returns its result.
+func WaitWithResult(chk checker.Checker, opts ...Option) (*Result, error) {
+	return WaitContextWithResult(context.Background(), chk, opts...)
+}
+
+// WaitContextWithResult waits for end up of check execution and returns its result.
+func WaitContextWithResult(ctx context.Context, chk checker.Checker, opts ...Option) (*Result, error) {
+	rr := newResultRecorder(chk)
+	err := WaitContext(ctx, chk, withOptions(opts, WithObserver(rr))...)
+
+	return rr.result, err
+}
+
+// WaitParallelWithResult waits for end up all of checks execution and returns their results
+// in the order of the checkers.
+func WaitParallelWithResult(checkers []checker.Checker, opts ...Option) ([]*Result, error) {
+	return WaitParallelContextWithResult(context.Background(), checkers, opts...)
+}
+
+// WaitParallelContextWithResult waits for end up all of checks execution and returns their results
+// in the order of the checkers.
+func WaitParallelContextWithResult(ctx context.Context, checkers []checker.Checker, opts ...Option) ([]*Result, error) {
+	recorders := make([]*resultRecorder, len(checkers))
+	for i, chk := range checkers {
+		recorders[i] = newResultRecorder(chk)
+	}
+
+	_, err := waitMany(ctx, checkers, len(checkers), opts, func(i int) []Option {
+		return []Option{WithObserver(recorders[i])}
+	})
+
+	results := make([]*Result, len(recorders))
+	for i, rr := range recorders {
+		results[i] = rr.result
+	}
+
+	return results, err
+}
+
+// withOptions returns a new list of options without modifying the given one.
+func withOptions(opts []Option, extra ...Option) []Option {
+	return append(append(make([]Option, 0, len(opts)+len(extra)), opts...), extra...)
+}
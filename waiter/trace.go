@@ -0,0 +1,124 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"wait4x.dev/v3/checker"
+)
+
+// tracerName is the instrumentation scope of the waiter spans.
+const tracerName = "wait4x.dev/v3/waiter"
+
+// These are the names of the waiter spans.
+const (
+	SpanWait         = "wait4x.wait"
+	SpanWaitParallel = "wait4x.wait_parallel"
+	SpanAttempt      = "wait4x.attempt"
+)
+
+// WithTracerProvider configures the OpenTelemetry tracer provider of the waiting spans.
+// The global tracer provider is used by default, which doesn't record anything unless
+// it's set with otel.SetTracerProvider.
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracerProvider = tracerProvider
+	}
+}
+
+// tracer returns the tracer of the configured tracer provider.
+func (o *options) tracer() trace.Tracer {
+	tp := o.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	return tp.Tracer(tracerName)
+}
+
+// checkerAttributes returns the span attributes describing a checker.
+func checkerAttributes(chk checker.Checker, name, id string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("wait4x.checker.name", name),
+		attribute.String("wait4x.checker.type", CheckerType(chk)),
+		attribute.String("wait4x.checker.identity", id),
+	}
+
+	labels := checkerLabels(chk)
+	for i := 0; i+1 < len(labels); i += 2 {
+		attrs = append(attrs, attribute.String(fmt.Sprintf("wait4x.checker.label.%s", labels[i]), fmt.Sprint(labels[i+1])))
+	}
+
+	return attrs
+}
+
+// endSpan ends a waiting span with the number of attempts and the result of the waiting.
+func endSpan(span trace.Span, attempts int, err error) {
+	span.SetAttributes(attribute.Int("wait4x.attempts", attempts))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// endAttemptSpan ends the span of a check attempt. A failed attempt is recorded as an event,
+// with the details of the failed expectation when the check failed with a checker.ExpectedError.
+func endAttemptSpan(span trace.Span, err error) {
+	if err != nil {
+		var attrs []attribute.KeyValue
+		var expectedError *checker.ExpectedError
+		if errors.As(err, &expectedError) {
+			attrs = detailAttributes(expectedError.Details())
+		}
+
+		span.RecordError(err, trace.WithAttributes(attrs...))
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// detailAttributes converts the key/value details of an ExpectedError to span attributes.
+func detailAttributes(keysAndValues []any) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key := attribute.Key(fmt.Sprintf("wait4x.detail.%v", keysAndValues[i]))
+
+		switch v := keysAndValues[i+1].(type) {
+		case bool:
+			attrs = append(attrs, key.Bool(v))
+		case int:
+			attrs = append(attrs, key.Int(v))
+		case int64:
+			attrs = append(attrs, key.Int64(v))
+		case float64:
+			attrs = append(attrs, key.Float64(v))
+		case string:
+			attrs = append(attrs, key.String(v))
+		default:
+			attrs = append(attrs, key.String(fmt.Sprint(v)))
+		}
+	}
+
+	return attrs
+}
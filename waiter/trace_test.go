@@ -0,0 +1,97 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"wait4x.dev/v3/checker"
+)
+
+func TestWaitTracing(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(checker.NewExpectedError("unexpected status code", nil, "actual", 503)).Once().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	err := Wait(checker.WithLabels(mockChecker, "api", map[string]string{"env": "ci"}), WithInterval(time.Millisecond), WithTracerProvider(tp))
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+
+	failed, passed, wait := spans[0], spans[1], spans[2]
+	assert.Equal(t, SpanWait, wait.Name())
+	assert.Contains(t, wait.Attributes(), attribute.String("wait4x.checker.name", "api"))
+	assert.Contains(t, wait.Attributes(), attribute.String("wait4x.checker.type", "MockChecker"))
+	assert.Contains(t, wait.Attributes(), attribute.String("wait4x.checker.identity", "ID"))
+	assert.Contains(t, wait.Attributes(), attribute.String("wait4x.checker.label.env", "ci"))
+	assert.Contains(t, wait.Attributes(), attribute.Int("wait4x.attempts", 2))
+	assert.Equal(t, codes.Unset, wait.Status().Code)
+
+	assert.Equal(t, SpanAttempt, failed.Name())
+	assert.Equal(t, wait.SpanContext().SpanID(), failed.Parent().SpanID())
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Len(t, failed.Events(), 1)
+	assert.Contains(t, failed.Events()[0].Attributes, attribute.Int("wait4x.detail.actual", 503))
+	assert.Contains(t, failed.Events()[0].Attributes, attribute.String("exception.message", "unexpected status code"))
+
+	assert.Equal(t, SpanAttempt, passed.Name())
+	assert.Contains(t, passed.Attributes(), attribute.Int("wait4x.attempt", 2))
+	assert.Empty(t, passed.Events())
+}
+
+func TestWaitParallelTracing(t *testing.T) {
+	ready := new(checker.MockChecker)
+	ready.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID1", nil)
+
+	failing := new(checker.MockChecker)
+	failing.On("Check", mock.Anything).Return(errors.New("error")).
+		On("Identity").Return("ID2", nil)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	err := WaitParallel([]checker.Checker{ready, failing}, WithTimeout(20*time.Millisecond), WithInterval(5*time.Millisecond), WithTracerProvider(tp))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	spans := recorder.Ended()
+	parallel := spans[len(spans)-1]
+	assert.Equal(t, SpanWaitParallel, parallel.Name())
+	assert.Contains(t, parallel.Attributes(), attribute.Int("wait4x.ready", 1))
+	assert.Equal(t, codes.Error, parallel.Status().Code)
+
+	var waits int
+	for _, span := range spans {
+		if span.Name() == SpanWait {
+			waits++
+			assert.Equal(t, parallel.SpanContext().SpanID(), span.Parent().SpanID())
+		}
+	}
+	assert.Equal(t, 2, waits)
+}
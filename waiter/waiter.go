@@ -19,10 +19,15 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"wait4x.dev/v3/checker"
 )
 
@@ -32,6 +37,20 @@ const (
 	BackoffPolicyLinear = "linear"
 	// BackoffPolicyExponential indicates an exponential backoff policy.
 	BackoffPolicyExponential = "exponential"
+	// BackoffPolicyFibonacci indicates a Fibonacci backoff policy.
+	BackoffPolicyFibonacci = "fibonacci"
+	// BackoffPolicyDecorrelatedJitter indicates a decorrelated jitter backoff policy.
+	BackoffPolicyDecorrelatedJitter = "decorrelated-jitter"
+)
+
+// Constants representing the available jitter strategies applied on top of a backoff policy.
+const (
+	// BackoffJitterNone disables the jitter.
+	BackoffJitterNone = "none"
+	// BackoffJitterFull randomizes the whole backoff interval.
+	BackoffJitterFull = "full"
+	// BackoffJitterEqual keeps half of the backoff interval and randomizes the other half.
+	BackoffJitterEqual = "equal"
 )
 
 // Check represents the checker's check method.
@@ -43,12 +62,51 @@ type Option func(s *options)
 // options represents waiter options
 type options struct {
 	timeout                       time.Duration
+	deadline                      time.Time
+	attemptTimeout                time.Duration
 	interval                      time.Duration
 	invertCheck                   bool
 	logger                        logr.Logger
+	backoff                       Backoff
 	backoffPolicy                 string
+	backoffJitter                 string
 	backoffExponentialMaxInterval time.Duration
 	backoffCoefficient            float64
+	successThreshold              int
+	observers                     observers
+	failFast                      bool
+	maxConcurrency                int
+	limiter                       limiter
+	failureThreshold              int
+	initialDelay                  time.Duration
+	gracePeriod                   time.Duration
+	tracerProvider                trace.TracerProvider
+	clock                         Clock
+	index                         int
+}
+
+// newOptions creates the waiter options with the defaults and applies the list of options to them.
+func newOptions(opts ...Option) *options {
+	o := &options{
+		timeout:                       10 * time.Second,
+		interval:                      time.Second,
+		invertCheck:                   false,
+		logger:                        logr.Discard(),
+		backoffPolicy:                 BackoffPolicyLinear,
+		backoffJitter:                 BackoffJitterNone,
+		backoffExponentialMaxInterval: 5 * time.Second,
+		backoffCoefficient:            2.0,
+		successThreshold:              1,
+		failureThreshold:              1,
+		clock:                         realClock{},
+	}
+
+	// apply the list of options to waiter
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
 }
 
 // WithTimeout configures a time limit for whole of checking
@@ -58,6 +116,37 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithDeadline configures an absolute time limit for whole of checking, the zero time is unlimited.
+// When it's combined with WithTimeout, the earliest of them applies.
+func WithDeadline(deadline time.Time) Option {
+	return func(o *options) {
+		o.deadline = deadline
+	}
+}
+
+// WithInitialDelay configures a delay before the first check attempt, it counts towards the timeout
+func WithInitialDelay(initialDelay time.Duration) Option {
+	return func(o *options) {
+		o.initialDelay = initialDelay
+	}
+}
+
+// WithGracePeriod configures a warm-up period starting with the first check attempt. Failed checks
+// are retried as usual during the grace period, but they are logged at the debug level instead of
+// as errors.
+func WithGracePeriod(gracePeriod time.Duration) Option {
+	return func(o *options) {
+		o.gracePeriod = gracePeriod
+	}
+}
+
+// WithAttemptTimeout configures a time limit for each of check attempts, 0 is unlimited
+func WithAttemptTimeout(attemptTimeout time.Duration) Option {
+	return func(o *options) {
+		o.attemptTimeout = attemptTimeout
+	}
+}
+
 // WithInterval configures time duration for each of checking interval
 func WithInterval(interval time.Duration) Option {
 	return func(o *options) {
@@ -86,8 +175,23 @@ func WithBackoffPolicy(backoffPolicy string) Option {
 	}
 }
 
+// WithBackoff returns an Option that sets a custom Backoff strategy for retries.
+// It takes precedence over the backoff policy, jitter, coefficient and max interval options.
+func WithBackoff(backoff Backoff) Option {
+	return func(o *options) {
+		o.backoff = backoff
+	}
+}
+
+// WithBackoffJitter returns an Option that sets the jitter applied on top of the backoff policy.
+func WithBackoffJitter(backoffJitter string) Option {
+	return func(o *options) {
+		o.backoffJitter = backoffJitter
+	}
+}
+
 // WithBackoffExponentialMaxInterval is a function that returns an Option which sets the
-// maximum interval time duration of the exponential backoff algorithm.
+// maximum interval time duration of the exponential, Fibonacci and decorrelated jitter backoff algorithms.
 func WithBackoffExponentialMaxInterval(backoffExponentialMaxInterval time.Duration) Option {
 	return func(o *options) {
 		o.backoffExponentialMaxInterval = backoffExponentialMaxInterval
@@ -101,47 +205,216 @@ func WithBackoffCoefficient(backoffCoefficient float64) Option {
 	}
 }
 
+// WithSuccessThreshold configures the number of consecutive successful checks
+// required before the target is considered ready. Any failed check resets the counter.
+func WithSuccessThreshold(successThreshold int) Option {
+	return func(o *options) {
+		o.successThreshold = successThreshold
+	}
+}
+
+// WithObserver registers an Observer which is notified about the check attempts.
+// It can be used multiple times to register several observers.
+func WithObserver(observer Observer) Option {
+	return func(o *options) {
+		o.observers = append(o.observers, observer)
+	}
+}
+
+// WithFailFast configures the parallel waiting to cancel the other checkers as soon as one of them fails
+func WithFailFast(failFast bool) Option {
+	return func(o *options) {
+		o.failFast = failFast
+	}
+}
+
+// WithFailureThreshold configures the number of consecutive failed checks after which a watched
+// checker is considered unhealthy, see Watch.
+func WithFailureThreshold(failureThreshold int) Option {
+	return func(o *options) {
+		o.failureThreshold = failureThreshold
+	}
+}
+
+// WithMaxConcurrency configures the maximum number of check attempts running at the same time
+// during a parallel waiting, 0 is unlimited. The checkers waiting for a free slot are served
+// in order, so every retry loop keeps making progress.
+func WithMaxConcurrency(maxConcurrency int) Option {
+	return func(o *options) {
+		o.maxConcurrency = maxConcurrency
+	}
+}
+
+// withIndex sets the position of the checker in the checkers of a waiting, see WaitingObserver.
+func withIndex(index int) Option {
+	return func(o *options) {
+		o.index = index
+	}
+}
+
+// withLimiter shares a limiter between the checkers of a parallel waiting.
+func withLimiter(l limiter) Option {
+	return func(o *options) {
+		o.limiter = l
+	}
+}
+
 // WaitParallel waits for end up all of checks execution.
 func WaitParallel(checkers []checker.Checker, opts ...Option) error {
 	return WaitParallelContext(context.Background(), checkers, opts...)
 }
 
 // WaitParallelContext waits for end up all of checks execution.
+//
+// When some checkers fail, the returned error joins a CheckerError for each of them
+// in the order of the checkers. With WithFailFast the first failure cancels the other
+// checkers, and only the failures that weren't caused by that cancellation are reported.
+// All the goroutines have exited when the function returns.
 func WaitParallelContext(ctx context.Context, checkers []checker.Checker, opts ...Option) error {
-	// Make channels to pass wgErrors in WaitGroup
-	wgErrors := make(chan error)
-	wgDone := make(chan bool)
+	_, err := waitMany(ctx, checkers, len(checkers), opts, nil)
+
+	return err
+}
+
+// WaitAny waits for end up of the first successful check execution.
+func WaitAny(checkers []checker.Checker, opts ...Option) (checker.Checker, error) {
+	return WaitAnyContext(context.Background(), checkers, opts...)
+}
+
+// WaitAnyContext waits for end up of the first successful check execution and returns
+// the ready checker. The other checkers are canceled as soon as one of them is ready.
+//
+// When all the checkers fail, the returned error joins a CheckerError for each of them.
+func WaitAnyContext(ctx context.Context, checkers []checker.Checker, opts ...Option) (checker.Checker, error) {
+	if len(checkers) == 0 {
+		return nil, errors.New("at least one checker is required")
+	}
+
+	ready, err := waitMany(ctx, checkers, 1, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkers[ready[0]], nil
+}
+
+// WaitQuorum waits for end up of at least n successful check executions.
+func WaitQuorum(checkers []checker.Checker, n int, opts ...Option) ([]checker.Checker, error) {
+	return WaitQuorumContext(context.Background(), checkers, n, opts...)
+}
+
+// WaitQuorumContext waits for end up of at least n successful check executions and returns
+// the ready checkers in the order they became ready. The other checkers are canceled as soon
+// as the quorum is reached.
+//
+// When the quorum isn't reached, the returned error joins a CheckerError for each failed checker.
+func WaitQuorumContext(ctx context.Context, checkers []checker.Checker, n int, opts ...Option) ([]checker.Checker, error) {
+	if n < 1 || n > len(checkers) {
+		return nil, fmt.Errorf("invalid quorum: %d of %d checkers", n, len(checkers))
+	}
+
+	indexes, err := waitMany(ctx, checkers, n, opts, nil)
+
+	ready := make([]checker.Checker, len(indexes))
+	for i, idx := range indexes {
+		ready[i] = checkers[idx]
+	}
+
+	if err != nil {
+		return ready, fmt.Errorf("quorum not reached, %d of %d required checkers are ready: %w", len(ready), n, err)
+	}
+
+	return ready, nil
+}
+
+// waitMany waits until the required number of checkers are ready, and returns the indexes
+// of the ready checkers in the order they became ready. The remaining checkers are canceled
+// once the required number is reached. checkerOpts optionally returns the extra options of
+// the i-th checker.
+func waitMany(
+	ctx context.Context,
+	checkers []checker.Checker,
+	required int,
+	opts []Option,
+	checkerOpts func(i int) []Option,
+) ([]int, error) {
+	options := newOptions(opts...)
+	opts, err := withSharedLimiter(opts, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// The spans of the checkers are children of the parallel waiting span
+	ctx, span := options.tracer().Start(ctx, SpanWaitParallel, trace.WithAttributes(
+		attribute.Int("wait4x.checkers", len(checkers)),
+		attribute.Int("wait4x.required", required),
+	))
+	defer span.End()
+
+	groupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Every goroutine writes its own error slot, so no synchronization is needed
+	errs := make([]error, len(checkers))
+
+	var mu sync.Mutex
+	var ready []int
+	// stopped reports whether the remaining checkers were canceled on purpose
+	var stopped atomic.Bool
 
 	var wg sync.WaitGroup
 
-	for _, chr := range checkers {
+	for i, chr := range checkers {
+		chrOpts := withOptions(opts, withIndex(i))
+		if checkerOpts != nil {
+			chrOpts = withOptions(chrOpts, checkerOpts(i)...)
+		}
+
 		wg.Add(1)
 
-		go func(chr checker.Checker) {
+		go func(i int, chr checker.Checker, opts []Option) {
 			defer wg.Done()
 
-			err := WaitContext(ctx, chr, opts...)
-			if err != nil {
-				wgErrors <- err
+			err := WaitContext(groupCtx, chr, opts...)
+			if err == nil {
+				mu.Lock()
+				ready = append(ready, i)
+				reached := len(ready) == required
+				mu.Unlock()
+
+				if reached {
+					stopped.Store(true)
+					cancel()
+				}
+
+				return
 			}
-		}(chr)
-	}
 
-	// Important final goroutine to wait until WaitGroup is done
-	go func() {
-		wg.Wait()
-		close(wgDone)
-	}()
+			// Ignore the checkers canceled on purpose
+			if errors.Is(err, context.Canceled) && stopped.Load() && ctx.Err() == nil {
+				return
+			}
 
-	// Wait until either WaitGroup is done or an error is received through the channel
-	select {
-	case <-wgDone:
-		return nil
-	case err := <-wgErrors:
-		close(wgErrors)
+			errs[i] = newCheckerError(chr, err)
 
-		return err
+			if options.failFast {
+				stopped.Store(true)
+				cancel()
+			}
+		}(i, chr, chrOpts)
+	}
+
+	wg.Wait()
+
+	span.SetAttributes(attribute.Int("wait4x.ready", len(ready)))
+	if len(ready) >= required {
+		return ready, nil
 	}
+
+	err = errors.Join(errs...)
+	span.SetStatus(codes.Error, err.Error())
+
+	return ready, err
 }
 
 // Wait waits for end up of check execution.
@@ -150,89 +423,258 @@ func Wait(checker checker.Checker, opts ...Option) error {
 }
 
 // WaitContext waits for end up of check execution.
+//
+// When the time runs out, the returned TimeoutError wraps context.DeadlineExceeded and the error
+// of the last failed check attempt.
+//
+// The waiting is traced with a span, and each check attempt with a child span of it,
+// see WithTracerProvider.
 func WaitContext(ctx context.Context, chk checker.Checker, opts ...Option) error {
-	options := &options{
-		timeout:                       10 * time.Second,
-		interval:                      time.Second,
-		invertCheck:                   false,
-		logger:                        logr.Discard(),
-		backoffPolicy:                 BackoffPolicyLinear,
-		backoffExponentialMaxInterval: 5 * time.Second,
-		backoffCoefficient:            2.0,
-	}
+	options := newOptions(opts...)
+	options.observers = options.observers.forWaiting(chk, options.index)
 
-	// apply the list of options to waiter
-	for _, opt := range opts {
-		opt(options)
-	}
+	return waitContext(ctx, chk, options)
+}
 
-	// Ignore timeout context when the timeout is unlimited
-	if options.timeout != 0 {
-		var cancel func()
-		ctx, cancel = context.WithTimeout(ctx, options.timeout)
-		defer cancel()
+// waitContext waits for the checker with the observers of the waiting.
+func waitContext(ctx context.Context, chk checker.Checker, options *options) (err error) {
+	if options.successThreshold < 1 {
+		return fmt.Errorf("invalid success threshold: %d", options.successThreshold)
 	}
 
-	var chkName string
-	if t := reflect.TypeOf(chk); t.Kind() == reflect.Ptr {
-		chkName = t.Elem().Name()
-	} else {
-		chkName = t.Name()
+	backoff, err := newBackoff(options)
+	if err != nil {
+		return err
 	}
 
+	ctx, cancel := options.withTimeLimit(ctx)
+	defer cancel()
+
+	chkName := CheckerName(chk)
+	logger := options.logger.WithValues(checkerLabels(chk)...)
+
+	// An invalid identity, e.g. a malformed DSN, can't be resolved by retrying
 	chkID, err := chk.Identity()
 	if err != nil {
-		return err
+		return checker.NewPermanentError(err)
 	}
 
-	//This is a counter for exponential backoff
+	// This is a counter for the backoff strategies
 	retries := 0
+	// This is a counter for the check attempts, recorded on the span
+	attempts := 0
+	// These are the last error and the distinct errors of the failed checks, reported on timeout
+	var lastErr error
+	var history failureHistory
+
+	ctx, span := options.tracer().Start(ctx, SpanWait, trace.WithAttributes(checkerAttributes(chk, chkName, chkID)...))
+	defer func() {
+		endSpan(span, attempts, err)
+	}()
+
+	// This is a counter for consecutive successful checks
+	successes := 0
+	// This is the previous waiting time, used by the backoff strategies
+	var waitDuration time.Duration
+
+	start := options.clock.Now()
+	options.observers.OnStart(chk)
+
+	if options.initialDelay > 0 {
+		logger.Info(fmt.Sprintf("[%s] Waiting %s before checking the %s ...", chkName, options.initialDelay, chkID))
+
+		if err := options.sleep(ctx, options.initialDelay); err != nil {
+			err = newTimeoutError(err, 0, nil, nil)
+			options.observers.OnGiveUp(chk, 0, options.since(start), err)
+			return err
+		}
+	}
+
+	// The grace period starts with the first check attempt
+	graceEnd := options.clock.Now().Add(options.gracePeriod)
 
 	for {
-		options.logger.Info(fmt.Sprintf("[%s] Checking the %s ...", chkName, chkID))
+		if err := options.limiter.acquire(ctx); err != nil {
+			err = newTimeoutError(err, attempts, lastErr, history)
+			options.observers.OnGiveUp(chk, retries, options.since(start), err)
+			return err
+		}
+
+		// The check is only logged once it's allowed to run
+		logger.Info(fmt.Sprintf("[%s] Checking the %s ...", chkName, chkID))
 
-		err := chk.Check(ctx)
+		attemptStart := options.clock.Now()
+		attemptCtx, attemptSpan := options.tracer().Start(ctx, SpanAttempt, trace.WithAttributes(attribute.Int("wait4x.attempt", retries+1)))
+		err := check(attemptCtx, chk, options)
+		endAttemptSpan(attemptSpan, err)
+		attempts++
+		options.limiter.release()
+		options.observers.OnAttempt(chk, Attempt{Number: retries + 1, Duration: options.since(attemptStart), Err: err})
 		if err != nil {
 			var expectedError *checker.ExpectedError
-			if errors.As(err, &expectedError) {
-				options.logger.Error(expectedError, "Expectation failed", expectedError.Details()...)
-			} else {
-				if !errors.Is(err, context.DeadlineExceeded) {
-					options.logger.Error(err, "Error occurred")
+			isExpectedError := errors.As(err, &expectedError)
+			switch {
+			case options.clock.Now().Before(graceEnd):
+				// Failures are expected while the target warms up, so they are only logged at the debug level
+				keysAndValues := []any{"error", err.Error()}
+				if isExpectedError {
+					keysAndValues = append(keysAndValues, expectedError.Details()...)
 				}
+				logger.V(1).Info("Check failed during the grace period", keysAndValues...)
+			case isExpectedError:
+				logger.Error(expectedError, "Expectation failed", expectedError.Details()...)
+			case !errors.Is(err, context.DeadlineExceeded):
+				logger.Error(err, "Error occurred")
 			}
 		}
 
-		var waitDuration time.Duration
-		if options.backoffPolicy == BackoffPolicyExponential {
-			waitDuration = exponentialBackoff(retries, options.backoffCoefficient, options.interval, options.backoffExponentialMaxInterval)
-		} else if options.backoffPolicy == BackoffPolicyLinear {
-			waitDuration = options.interval
+		// In the invert mode a failed check counts as a successful one, unless it failed permanently,
+		// e.g. because of a malformed DSN or rejected credentials.
+		permanent := checker.IsPermanentError(err)
+		if (err == nil) != (options.invertCheck && !permanent) {
+			successes++
+			if successes >= options.successThreshold {
+				options.observers.OnReady(chk, retries+1, options.since(start))
+				break
+			}
 		} else {
-			return fmt.Errorf("invalid backoff policy: %s", options.backoffPolicy)
-		}
+			successes = 0
 
-		if options.invertCheck == true {
-			if err == nil {
-				goto CONTINUE
+			// The attempt cut off by the deadline failed because of it, not because of the target
+			if ctx.Err() == nil {
+				lastErr = err
+				if err != nil {
+					history.add(err)
+				}
 			}
 
-			break
-		}
-
-		if err == nil {
-			break
+			// Retrying can't resolve a permanent error, unless the target is still warming up
+			if permanent && !options.clock.Now().Before(graceEnd) {
+				logger.Info(fmt.Sprintf("[%s] Stopped retrying the %s, the check failed permanently", chkName, chkID))
+				err = fmt.Errorf("%w: %w", ErrPermanent, err)
+				options.observers.OnGiveUp(chk, retries+1, options.since(start), err)
+				return err
+			}
 		}
 
-	CONTINUE:
+		waitDuration = backoff.Next(retries, waitDuration)
 		retries++
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(waitDuration):
+		if err := options.sleep(ctx, waitDuration); err != nil {
+			err = newTimeoutError(err, attempts, lastErr, history)
+			options.observers.OnGiveUp(chk, retries, options.since(start), err)
+			return err
 		}
-
 	}
 
 	return nil
 }
+
+// CheckerName returns the name of a checker implementing checker.Named, or the type name of the checker.
+func CheckerName(chk checker.Checker) string {
+	if named, ok := chk.(checker.Named); ok && named.Name() != "" {
+		return named.Name()
+	}
+
+	// Wrappers without a name are named after the checker they wrap
+	if wrapper, ok := chk.(interface{ Unwrap() checker.Checker }); ok {
+		return CheckerName(wrapper.Unwrap())
+	}
+
+	return CheckerType(chk)
+}
+
+// CheckerType returns the type name of the checker, the wrappers like checker.WithLabels are
+// looked through.
+func CheckerType(chk checker.Checker) string {
+	if wrapper, ok := chk.(interface{ Unwrap() checker.Checker }); ok {
+		return CheckerType(wrapper.Unwrap())
+	}
+
+	if t := reflect.TypeOf(chk); t.Kind() == reflect.Ptr {
+		return t.Elem().Name()
+	}
+
+	return reflect.TypeOf(chk).Name()
+}
+
+// checkerLabels returns the labels of a checker implementing checker.Named as key/value pairs sorted by key.
+func checkerLabels(chk checker.Checker) []any {
+	named, ok := chk.(checker.Named)
+	if !ok {
+		return nil
+	}
+
+	labels := named.Labels()
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kv := make([]any, 0, 2*len(keys))
+	for _, k := range keys {
+		kv = append(kv, k, labels[k])
+	}
+
+	return kv
+}
+
+// check runs a single check attempt, bounded by the attempt timeout when it's set.
+func check(ctx context.Context, chk checker.Checker, options *options) error {
+	if options.attemptTimeout == 0 {
+		return chk.Check(ctx)
+	}
+
+	attemptCtx, cancel := options.withTimeout(ctx, options.attemptTimeout)
+	defer cancel()
+
+	err := chk.Check(attemptCtx)
+	// The attempt ran out of time while the whole wait still has time left.
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		return checker.NewExpectedError("timed out while running the check attempt", err, "attempt-timeout", options.attemptTimeout)
+	}
+
+	return err
+}
+
+// withSharedLimiter adds a limiter shared between all the checkers of a parallel waiting
+// when the max concurrency is set.
+func withSharedLimiter(opts []Option, o *options) ([]Option, error) {
+	if o.maxConcurrency < 0 {
+		return nil, fmt.Errorf("invalid max concurrency: %d", o.maxConcurrency)
+	}
+
+	if o.maxConcurrency == 0 || o.limiter != nil {
+		return opts, nil
+	}
+
+	return withOptions(opts, withLimiter(newLimiter(o.maxConcurrency))), nil
+}
+
+// limiter bounds the number of concurrent check attempts, a nil limiter is unlimited.
+type limiter chan struct{}
+
+func newLimiter(size int) limiter {
+	return make(limiter, size)
+}
+
+// acquire blocks until a slot is free or the context is done.
+func (l limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot taken by acquire.
+func (l limiter) release() {
+	if l != nil {
+		<-l
+	}
+}
@@ -23,9 +23,13 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/tonglil/buflogr"
 	"os"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/combinator"
 )
 
 func TestMain(m *testing.M) {
@@ -50,10 +54,155 @@ func TestWaitTimedOut(t *testing.T) {
 
 	err := Wait(mockChecker, WithTimeout(time.Second))
 
-	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
 	mockChecker.AssertExpectations(t)
 }
 
+func TestWaitDeadline(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(fmt.Errorf("error message")).
+		On("Identity").Return("ID", nil)
+
+	start := time.Now()
+	err := Wait(mockChecker, WithTimeout(10*time.Second), WithDeadline(start.Add(100*time.Millisecond)), WithInterval(10*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The timeout still applies when it's earlier than the deadline
+	start = time.Now()
+	err = Wait(mockChecker, WithTimeout(100*time.Millisecond), WithDeadline(start.Add(10*time.Second)), WithInterval(10*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWaitInitialDelay(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	start := time.Now()
+	err := Wait(mockChecker, WithInitialDelay(100*time.Millisecond))
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// The initial delay counts towards the timeout
+	delayed := new(checker.MockChecker)
+	delayed.On("Identity").Return("ID", nil)
+
+	err = Wait(delayed, WithTimeout(50*time.Millisecond), WithInitialDelay(time.Second))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	delayed.AssertNotCalled(t, "Check", mock.Anything)
+}
+
+func TestWaitGracePeriod(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(checker.NewExpectedError("warming up", nil)).Twice().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	var buf bytes.Buffer
+	err := Wait(mockChecker, WithInterval(10*time.Millisecond), WithGracePeriod(time.Minute), WithLogger(buflogr.NewWithBuffer(&buf)))
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "V[1] Check failed during the grace period error warming up")
+	assert.NotContains(t, buf.String(), "ERROR")
+
+	// The failures after the grace period are logged as errors
+	failing := new(checker.MockChecker)
+	failing.On("Check", mock.Anything).Return(checker.NewExpectedError("warming up", nil)).
+		On("Identity").Return("ID", nil)
+
+	buf.Reset()
+	err = Wait(failing, WithTimeout(100*time.Millisecond), WithInterval(10*time.Millisecond), WithGracePeriod(30*time.Millisecond), WithLogger(buflogr.NewWithBuffer(&buf)))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, buf.String(), "V[1] Check failed during the grace period")
+	assert.Contains(t, buf.String(), "ERROR warming up Expectation failed")
+}
+
+func TestWaitPermanentError(t *testing.T) {
+	authenticationError := checker.NewAuthenticationError("failed to authenticate to the mysql server", errors.New("access denied"))
+
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(authenticationError).
+		On("Identity").Return("ID", nil)
+
+	var buf bytes.Buffer
+	err := Wait(mockChecker, WithTimeout(time.Minute), WithInterval(10*time.Millisecond), WithLogger(buflogr.NewWithBuffer(&buf)))
+	assert.ErrorIs(t, err, ErrPermanent)
+	assert.ErrorIs(t, err, authenticationError)
+	assert.EqualError(t, err, "the check failed permanently: failed to authenticate to the mysql server, caused by: access denied")
+	assert.Contains(t, buf.String(), "[MockChecker] Stopped retrying the ID, the check failed permanently")
+	mockChecker.AssertNumberOfCalls(t, "Check", 1)
+
+	// The permanent errors are retried during the grace period
+	warmingUp := new(checker.MockChecker)
+	warmingUp.On("Check", mock.Anything).Return(authenticationError).Twice().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	err = Wait(warmingUp, WithInterval(10*time.Millisecond), WithGracePeriod(time.Minute))
+	assert.NoError(t, err)
+	warmingUp.AssertNumberOfCalls(t, "Check", 3)
+
+	// A permanent error doesn't count as a successful check in the invert mode
+	err = Wait(mockChecker, WithTimeout(time.Minute), WithInvertCheck(true))
+	assert.ErrorIs(t, err, ErrPermanent)
+	assert.ErrorIs(t, err, authenticationError)
+	mockChecker.AssertNumberOfCalls(t, "Check", 2)
+}
+
+func TestWaitTimeoutError(t *testing.T) {
+	refused := checker.NewExpectedError("failed to establish a tcp connection", nil)
+	unexpected := checker.NewExpectedError("the status code doesn't expect", nil, "actual", 503)
+
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(refused).Twice().
+		On("Check", mock.Anything).Return(unexpected).
+		On("Identity").Return("ID", nil)
+
+	err := Wait(mockChecker, WithTimeout(100*time.Millisecond), WithInterval(10*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, unexpected)
+
+	var timeoutError *TimeoutError
+	assert.ErrorAs(t, err, &timeoutError)
+	assert.Equal(t, unexpected, timeoutError.LastErr)
+	assert.Greater(t, timeoutError.Attempts, 2)
+	assert.Len(t, timeoutError.History, 2)
+	assert.Equal(t, Failure{Err: refused, Count: 2}, timeoutError.History[0])
+	assert.Equal(t, Failure{Err: unexpected, Count: timeoutError.Attempts - 2}, timeoutError.History[1])
+	assert.Equal(t, fmt.Sprintf("context deadline exceeded after %d attempts, last error: the status code doesn't expect", timeoutError.Attempts), err.Error())
+
+	// The attempt cut off by the deadline doesn't hide the failures before it
+	hanging := new(checker.MockChecker)
+	hanging.On("Check", mock.Anything).Return(unexpected).Once().
+		On("Check", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.DeadlineExceeded).
+		On("Identity").Return("ID", nil)
+
+	err = Wait(hanging, WithTimeout(100*time.Millisecond), WithInterval(10*time.Millisecond))
+	assert.ErrorAs(t, err, &timeoutError)
+	assert.Equal(t, unexpected, timeoutError.LastErr)
+	assert.Equal(t, 2, timeoutError.Attempts)
+	assert.Equal(t, []Failure{{Err: unexpected, Count: 1}}, timeoutError.History)
+	assert.EqualError(t, err, "context deadline exceeded after 2 attempts, last error: the status code doesn't expect")
+
+	// The history keeps only the first distinct failures
+	var history failureHistory
+	for i := 0; i < maxFailureHistory+2; i++ {
+		history.add(fmt.Errorf("error %d", i))
+	}
+	history.add(errors.New("error 0"))
+	assert.Len(t, history, maxFailureHistory)
+	assert.Equal(t, 2, history[0].Count)
+
+	// Waiting canceled by the caller isn't a timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = WaitContext(ctx, mockChecker)
+	assert.Equal(t, context.Canceled, err)
+}
+
 func TestWaitInvalidIdentity(t *testing.T) {
 	invalidIdentityError := errors.New("invalid identity")
 
@@ -62,7 +211,8 @@ func TestWaitInvalidIdentity(t *testing.T) {
 
 	err := Wait(mockChecker)
 
-	assert.Equal(t, invalidIdentityError, err)
+	assert.ErrorIs(t, err, invalidIdentityError)
+	assert.True(t, checker.IsPermanentError(err))
 	mockChecker.AssertExpectations(t)
 }
 
@@ -76,7 +226,7 @@ func TestWaitLogger(t *testing.T) {
 	var log = buflogr.NewWithBuffer(&buf)
 	err := WaitContext(context.TODO(), mockChecker, WithLogger(log), WithTimeout(time.Second))
 
-	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
 	assert.Contains(t, buf.String(), "INFO [MockChecker] Checking the ID ...")
 	assert.Contains(t, buf.String(), "error message")
 	mockChecker.AssertExpectations(t)
@@ -88,7 +238,7 @@ func TestWaitInvertCheck(t *testing.T) {
 		On("Identity").Return("ID", nil)
 
 	err := Wait(alwaysTrue, WithTimeout(time.Second*3), WithInvertCheck(true))
-	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
 	alwaysTrue.AssertExpectations(t)
 
 	alwaysFalse := new(checker.MockChecker)
@@ -129,9 +279,521 @@ func TestWaitParallelFail(t *testing.T) {
 		On("Identity").Return("ID", nil)
 
 	err := WaitParallel([]checker.Checker{alwaysTrueFirst, alwaysTrueSecond, alwaysError}, WithTimeout(time.Second*3))
-	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var checkerError *CheckerError
+	assert.ErrorAs(t, err, &checkerError)
+	assert.Equal(t, alwaysError, checkerError.Checker)
 
 	alwaysTrueFirst.AssertExpectations(t)
 	alwaysTrueSecond.AssertExpectations(t)
 	alwaysError.AssertExpectations(t)
 }
+
+func TestWaitSuccessThreshold(t *testing.T) {
+	flaky := new(checker.MockChecker)
+	flaky.On("Check", mock.Anything).Return(nil).Once().
+		On("Check", mock.Anything).Return(fmt.Errorf("error")).Once().
+		On("Check", mock.Anything).Return(nil).Twice().
+		On("Identity").Return("ID", nil)
+
+	err := Wait(flaky, WithTimeout(time.Second*3), WithInterval(10*time.Millisecond), WithSuccessThreshold(2))
+	assert.Nil(t, err)
+	flaky.AssertExpectations(t)
+	flaky.AssertNumberOfCalls(t, "Check", 4)
+}
+
+func TestWaitSuccessThresholdInvertCheck(t *testing.T) {
+	flaky := new(checker.MockChecker)
+	flaky.On("Check", mock.Anything).Return(fmt.Errorf("error")).Once().
+		On("Check", mock.Anything).Return(nil).Once().
+		On("Check", mock.Anything).Return(fmt.Errorf("error")).Times(3).
+		On("Identity").Return("ID", nil)
+
+	err := Wait(flaky, WithTimeout(time.Second*3), WithInterval(10*time.Millisecond), WithInvertCheck(true), WithSuccessThreshold(3))
+	assert.Nil(t, err)
+	flaky.AssertExpectations(t)
+	flaky.AssertNumberOfCalls(t, "Check", 5)
+}
+
+func TestWaitInvalidSuccessThreshold(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Identity").Return("ID", nil)
+
+	err := Wait(mockChecker, WithSuccessThreshold(0))
+	assert.EqualError(t, err, "invalid success threshold: 0")
+}
+
+func TestWaitAttemptTimeout(t *testing.T) {
+	slow := new(checker.MockChecker)
+	slow.On("Check", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.DeadlineExceeded).Twice().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	var buf bytes.Buffer
+	err := Wait(
+		slow,
+		WithTimeout(time.Second*3),
+		WithAttemptTimeout(50*time.Millisecond),
+		WithInterval(10*time.Millisecond),
+		WithLogger(buflogr.NewWithBuffer(&buf)),
+	)
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "timed out while running the check attempt")
+	slow.AssertNumberOfCalls(t, "Check", 3)
+}
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (ro *recordingObserver) record(event string) {
+	ro.mu.Lock()
+	defer ro.mu.Unlock()
+	ro.events = append(ro.events, event)
+}
+
+func (ro *recordingObserver) OnStart(checker.Checker) {
+	ro.record("start")
+}
+
+func (ro *recordingObserver) OnAttempt(_ checker.Checker, attempt Attempt) {
+	ro.record(fmt.Sprintf("attempt %d %v", attempt.Number, attempt.Err))
+}
+
+func (ro *recordingObserver) OnReady(_ checker.Checker, attempts int, _ time.Duration) {
+	ro.record(fmt.Sprintf("ready %d", attempts))
+}
+
+func (ro *recordingObserver) OnGiveUp(_ checker.Checker, attempts int, _ time.Duration, err error) {
+	ro.record(fmt.Sprintf("give up %d %v", attempts, err))
+}
+
+func TestWaitObserver(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(fmt.Errorf("error")).Once().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	observer := new(recordingObserver)
+	err := Wait(mockChecker, WithInterval(10*time.Millisecond), WithObserver(observer))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"start", "attempt 1 error", "attempt 2 <nil>", "ready 2"}, observer.events)
+}
+
+func TestWaitObserverGiveUp(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("ID", nil)
+
+	observer := new(recordingObserver)
+	err := Wait(mockChecker, WithTimeout(100*time.Millisecond), WithInterval(40*time.Millisecond), WithObserver(observer))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "start", observer.events[0])
+	assert.Equal(t, fmt.Sprintf("give up %d %s", len(observer.events)-2, err), observer.events[len(observer.events)-1])
+}
+
+func TestWaitParallelObserver(t *testing.T) {
+	first := new(checker.MockChecker)
+	first.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	second := new(checker.MockChecker)
+	second.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	observer := new(recordingObserver)
+	err := WaitParallel([]checker.Checker{first, second}, WithObserver(observer))
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"start", "start", "attempt 1 <nil>", "attempt 1 <nil>", "ready 1", "ready 1"}, observer.events)
+}
+
+func TestWaitWithResult(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(fmt.Errorf("first")).Once().
+		On("Check", mock.Anything).Return(fmt.Errorf("second")).Once().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	result, err := WaitWithResult(mockChecker, WithInterval(10*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Equal(t, mockChecker, result.Checker)
+	assert.Equal(t, "ID", result.Identity)
+	assert.True(t, result.Ready)
+	assert.Equal(t, 3, result.Attempts)
+	assert.Greater(t, result.TimeToReady, time.Duration(0))
+	assert.EqualError(t, result.LastError, "second")
+	assert.Len(t, result.History, 3)
+	assert.Nil(t, result.History[2].Err)
+}
+
+func TestWaitParallelWithResult(t *testing.T) {
+	alwaysTrue := new(checker.MockChecker)
+	alwaysTrue.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("first", nil)
+
+	flaky := new(checker.MockChecker)
+	flaky.On("Check", mock.Anything).Return(fmt.Errorf("error")).Once().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("second", nil)
+
+	results, err := WaitParallelWithResult([]checker.Checker{alwaysTrue, flaky}, WithInterval(10*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "first", results[0].Identity)
+	assert.Equal(t, 1, results[0].Attempts)
+	assert.Equal(t, "second", results[1].Identity)
+	assert.Equal(t, 2, results[1].Attempts)
+	assert.True(t, results[0].Ready && results[1].Ready)
+}
+
+func TestWaitNamedChecker(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("ID", nil)
+
+	chk := checker.WithLabels(mockChecker, "api", map[string]string{"team": "core", "env": "staging"})
+
+	var buf bytes.Buffer
+	results, err := WaitParallelWithResult(
+		[]checker.Checker{chk},
+		WithTimeout(50*time.Millisecond),
+		WithInterval(10*time.Millisecond),
+		WithLogger(buflogr.NewWithBuffer(&buf)),
+	)
+	assert.Regexp(t, `^api \(ID\): context deadline exceeded after \d+ attempts?, last error: error$`, err.Error())
+	assert.Contains(t, buf.String(), "INFO [api] Checking the ID ... env staging team core")
+	assert.Equal(t, "api", results[0].Name)
+	assert.Equal(t, map[string]string{"team": "core", "env": "staging"}, results[0].Labels)
+
+	// Unnamed checkers are named after their type
+	assert.Equal(t, "MockChecker", CheckerName(checker.WithLabels(mockChecker, "", nil)))
+
+	// The composed checkers are named with the combinator
+	assert.Equal(t, "db", CheckerName(combinator.WithName(mockChecker, "db")))
+}
+
+func TestResultCollector(t *testing.T) {
+	alwaysTrue := new(checker.MockChecker)
+	alwaysTrue.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("first", nil)
+
+	alwaysFalse := new(checker.MockChecker)
+	alwaysFalse.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("second", nil)
+
+	collector := NewResultCollector()
+	err := WaitParallel(
+		[]checker.Checker{alwaysTrue, alwaysFalse},
+		WithTimeout(50*time.Millisecond),
+		WithInterval(10*time.Millisecond),
+		WithObserver(collector),
+	)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The results are in the order of the checkers
+	results := collector.Results()
+	assert.Len(t, results, 2)
+	assert.Equal(t, "first", results[0].Identity)
+	assert.True(t, results[0].Ready)
+	assert.Equal(t, 1, results[0].Attempts)
+	assert.Equal(t, "second", results[1].Identity)
+	assert.False(t, results[1].Ready)
+	assert.EqualError(t, results[1].LastError, "error")
+	assert.Len(t, results[1].History, results[1].Attempts)
+
+	// The checkers of unhashable types, and the same checker waited for twice, have results of their own
+	unhashable := checkerFunc(func(context.Context) error { return nil })
+
+	collector = NewResultCollector()
+	err = WaitParallel([]checker.Checker{unhashable, alwaysTrue, alwaysTrue}, WithObserver(collector))
+	assert.NoError(t, err)
+
+	results = collector.Results()
+	assert.Len(t, results, 3)
+	assert.Equal(t, "func", results[0].Identity)
+	for _, r := range results {
+		assert.True(t, r.Ready)
+		assert.Equal(t, 1, r.Attempts)
+	}
+
+	// The checkers canceled once another one is ready aren't failed
+	collector = NewResultCollector()
+	_, err = WaitAny([]checker.Checker{alwaysTrue, alwaysFalse}, WithInterval(time.Second), WithObserver(collector))
+	assert.NoError(t, err)
+
+	results = collector.Results()
+	assert.Len(t, results, 2)
+	assert.True(t, results[0].Ready)
+	assert.False(t, results[0].Canceled)
+	assert.False(t, results[1].Ready)
+	assert.True(t, results[1].Canceled)
+
+	// The waitings which failed before they started have no result
+	invalidIdentity := new(checker.MockChecker)
+	invalidIdentity.On("Identity").Return("", errors.New("invalid identity"))
+
+	collector = NewResultCollector()
+	err = WaitParallel([]checker.Checker{invalidIdentity, alwaysTrue}, WithObserver(collector))
+	assert.EqualError(t, err, "invalid identity")
+
+	results = collector.Results()
+	assert.Len(t, results, 1)
+	assert.Equal(t, "first", results[0].Identity)
+}
+
+// checkerFunc is a checker of an unhashable type.
+type checkerFunc func(ctx context.Context) error
+
+func (f checkerFunc) Identity() (string, error) {
+	return "func", nil
+}
+
+func (f checkerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestWaitParallelAggregatedErrors(t *testing.T) {
+	alwaysTrue := new(checker.MockChecker)
+	alwaysTrue.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ready", nil)
+
+	firstError := new(checker.MockChecker)
+	firstError.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("first", nil)
+
+	secondError := new(checker.MockChecker)
+	secondError.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("second", nil)
+
+	err := WaitParallel([]checker.Checker{firstError, alwaysTrue, secondError}, WithTimeout(time.Second))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Regexp(t, `^first: context deadline exceeded after \d+ attempts?, last error: error\nsecond: context deadline exceeded after \d+ attempts?, last error: error$`, err.Error())
+}
+
+// assertNoGoroutineLeak asserts the number of goroutines goes back to the given baseline.
+func assertNoGoroutineLeak(t *testing.T, baseline int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.LessOrEqual(t, runtime.NumGoroutine(), baseline, "goroutines leaked")
+}
+
+func TestWaitParallelFailFast(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	blocking := new(checker.MockChecker)
+	blocking.On("Check", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.Canceled).
+		On("Identity").Return("blocking", nil)
+
+	invalidIdentity := new(checker.MockChecker)
+	invalidIdentity.On("Identity").Return("", errors.New("invalid identity"))
+
+	start := time.Now()
+	err := WaitParallel([]checker.Checker{blocking, invalidIdentity}, WithTimeout(5*time.Second), WithFailFast(true))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.EqualError(t, err, "invalid identity")
+	assert.NotErrorIs(t, err, context.Canceled)
+
+	assertNoGoroutineLeak(t, baseline)
+}
+
+func TestWaitParallelWithoutFailFast(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	flaky := new(checker.MockChecker)
+	flaky.On("Check", mock.Anything).Return(fmt.Errorf("error")).Twice().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("flaky", nil)
+
+	invalidIdentity := new(checker.MockChecker)
+	invalidIdentity.On("Identity").Return("", errors.New("invalid identity"))
+
+	err := WaitParallel([]checker.Checker{flaky, invalidIdentity}, WithInterval(10*time.Millisecond))
+	assert.EqualError(t, err, "invalid identity")
+	flaky.AssertNumberOfCalls(t, "Check", 3)
+
+	assertNoGoroutineLeak(t, baseline)
+}
+
+func TestWaitParallelNoGoroutineLeak(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	checkers := make([]checker.Checker, 20)
+	for i := range checkers {
+		alwaysError := new(checker.MockChecker)
+		alwaysError.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+			On("Identity").Return(fmt.Sprintf("ID-%d", i), nil)
+		checkers[i] = alwaysError
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	err := WaitParallelContext(ctx, checkers, WithInterval(10*time.Millisecond))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assertNoGoroutineLeak(t, baseline)
+}
+
+func TestWaitAny(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	blocking := new(checker.MockChecker)
+	blocking.On("Check", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.Canceled).
+		On("Identity").Return("blocking", nil)
+
+	alwaysError := new(checker.MockChecker)
+	alwaysError.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("error", nil)
+
+	flaky := new(checker.MockChecker)
+	flaky.On("Check", mock.Anything).Return(fmt.Errorf("error")).Once().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("flaky", nil)
+
+	chk, err := WaitAny([]checker.Checker{blocking, alwaysError, flaky}, WithInterval(10*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Equal(t, flaky, chk)
+
+	assertNoGoroutineLeak(t, baseline)
+}
+
+func TestWaitAnyFail(t *testing.T) {
+	first := new(checker.MockChecker)
+	first.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("first", nil)
+
+	second := new(checker.MockChecker)
+	second.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("second", nil)
+
+	chk, err := WaitAny([]checker.Checker{first, second}, WithTimeout(100*time.Millisecond))
+	assert.Nil(t, chk)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Regexp(t, `^first: context deadline exceeded after \d+ attempts?, last error: error\nsecond: context deadline exceeded after \d+ attempts?, last error: error$`, err.Error())
+
+	_, err = WaitAny(nil)
+	assert.EqualError(t, err, "at least one checker is required")
+}
+
+func TestWaitQuorum(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	blocking := new(checker.MockChecker)
+	blocking.On("Check", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.Canceled).
+		On("Identity").Return("blocking", nil)
+
+	alwaysTrue := new(checker.MockChecker)
+	alwaysTrue.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ready", nil)
+
+	flaky := new(checker.MockChecker)
+	flaky.On("Check", mock.Anything).Return(fmt.Errorf("error")).Once().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("flaky", nil)
+
+	ready, err := WaitQuorum([]checker.Checker{blocking, alwaysTrue, flaky}, 2, WithInterval(10*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Equal(t, []checker.Checker{alwaysTrue, flaky}, ready)
+
+	assertNoGoroutineLeak(t, baseline)
+}
+
+func TestWaitQuorumFail(t *testing.T) {
+	alwaysTrue := new(checker.MockChecker)
+	alwaysTrue.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ready", nil)
+
+	alwaysError := new(checker.MockChecker)
+	alwaysError.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("error", nil)
+
+	ready, err := WaitQuorum([]checker.Checker{alwaysTrue, alwaysError}, 2, WithTimeout(100*time.Millisecond))
+	assert.Equal(t, []checker.Checker{alwaysTrue}, ready)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Regexp(t, `^quorum not reached, 1 of 2 required checkers are ready: error: context deadline exceeded after \d+ attempts?, last error: error$`, err.Error())
+
+	_, err = WaitQuorum([]checker.Checker{alwaysTrue}, 2)
+	assert.EqualError(t, err, "invalid quorum: 2 of 1 checkers")
+}
+
+func TestWaitParallelMaxConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	var mu sync.Mutex
+
+	checkers := make([]checker.Checker, 10)
+	for i := range checkers {
+		chk := new(checker.MockChecker)
+		chk.On("Check", mock.Anything).Run(func(mock.Arguments) {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}).Return(fmt.Errorf("error")).Twice().
+			On("Check", mock.Anything).Return(nil).
+			On("Identity").Return(fmt.Sprintf("ID-%d", i), nil)
+		checkers[i] = chk
+	}
+
+	err := WaitParallel(checkers, WithInterval(time.Millisecond), WithMaxConcurrency(3))
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, maxRunning, int32(3))
+	for _, chk := range checkers {
+		chk.(*checker.MockChecker).AssertNumberOfCalls(t, "Check", 3)
+	}
+
+	err = WaitParallel(checkers, WithMaxConcurrency(-1))
+	assert.EqualError(t, err, "invalid max concurrency: -1")
+}
+
+func TestWaitParallelMaxConcurrencyLogging(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+
+	checkers := make([]checker.Checker, 2)
+	for i := range checkers {
+		chk := new(checker.MockChecker)
+		chk.On("Check", mock.Anything).Run(func(mock.Arguments) {
+			once.Do(func() { close(started) })
+			<-release
+		}).Return(nil).
+			On("Identity").Return(fmt.Sprintf("ID-%d", i), nil)
+		checkers[i] = chk
+	}
+
+	var buf bytes.Buffer
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- WaitParallel(checkers, WithMaxConcurrency(1), WithLogger(buflogr.NewWithBuffer(&buf)))
+	}()
+
+	// The checker waiting for the slot isn't logged as being checked
+	<-started
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, strings.Count(buf.String(), "Checking the"))
+
+	close(release)
+	assert.Nil(t, <-errCh)
+	assert.Equal(t, 2, strings.Count(buf.String(), "Checking the"))
+}
@@ -0,0 +1,153 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package waitertest provides utilities for testing the code waiting with the waiter package.
+package waitertest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"wait4x.dev/v3/waiter"
+)
+
+// Clock is a fake waiter.Clock whose time only moves when it's advanced, so the timeout, the
+// intervals and the backoff of the waiting can be tested instantly and deterministically.
+//
+// The waiting runs in another goroutine, the test waits for it to block on the clock with
+// BlockUntil and then moves the time forward with Advance:
+//
+//	clock := waitertest.NewClock(time.Now())
+//	go func() {
+//		errCh <- waiter.WaitContext(ctx, chk, waiter.WithClock(clock), waiter.WithTimeout(time.Minute))
+//	}()
+//
+//	// The timeout and the interval timers
+//	clock.BlockUntil(2)
+//	clock.Advance(time.Second)
+type Clock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*timer
+}
+
+// NewClock creates the fake clock starting at the time.
+func NewClock(now time.Time) *Clock {
+	c := &Clock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+
+	return c
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After waits for the clock to be advanced by the duration and then sends the current time
+// on the returned channel.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a timer which fires once the clock is advanced by the duration,
+// a timer of a non-positive duration fires at once.
+func (c *Clock) NewTimer(d time.Duration) waiter.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &timer{
+		clock: c,
+		when:  c.now.Add(d),
+		c:     make(chan time.Time, 1),
+	}
+
+	if d <= 0 {
+		t.c <- c.now
+		return t
+	}
+
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+
+	return t
+}
+
+// Advance moves the time of the clock forward by the duration and fires the timers which
+// are due, in the order of their times.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].when.Before(c.timers[j].when)
+	})
+
+	i := 0
+	for ; i < len(c.timers) && !c.timers[i].when.After(c.now); i++ {
+		c.timers[i].c <- c.now
+	}
+	c.timers = c.timers[i:]
+
+	c.cond.Broadcast()
+}
+
+// BlockUntil blocks until at least n timers of the clock are waiting to fire, e.g. until
+// the waiting sleeps between the check attempts.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// stop removes the timer from the clock, it reports whether the timer was waiting to fire.
+func (c *Clock) stop(t *timer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.timers {
+		if c.timers[i] == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			c.cond.Broadcast()
+			return true
+		}
+	}
+
+	return false
+}
+
+// timer is a waiter.Timer of the fake clock.
+type timer struct {
+	clock *Clock
+	when  time.Time
+	c     chan time.Time
+}
+
+func (t *timer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *timer) Stop() bool {
+	return t.clock.stop(t)
+}
@@ -0,0 +1,196 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waitertest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/waiter"
+)
+
+var epoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// fired reports whether the channel received a time.
+func fired(c <-chan time.Time) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestClock(t *testing.T) {
+	clock := NewClock(epoch)
+
+	first := clock.NewTimer(2 * time.Second)
+	second := clock.After(time.Second)
+	stopped := clock.NewTimer(time.Second)
+	assert.True(t, fired(clock.After(0)))
+
+	clock.BlockUntil(3)
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, epoch.Add(time.Second), clock.Now())
+	assert.False(t, fired(first.C()))
+	assert.True(t, fired(second))
+	assert.False(t, fired(stopped.C()))
+
+	clock.Advance(time.Second)
+	assert.True(t, fired(first.C()))
+	assert.False(t, first.Stop())
+}
+
+// attemptRecorder records the clock time of the check attempts.
+type attemptRecorder struct {
+	mu    sync.Mutex
+	clock *Clock
+	times []time.Duration
+}
+
+func (ar *attemptRecorder) record(mock.Arguments) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	ar.times = append(ar.times, ar.clock.Now().Sub(epoch))
+}
+
+func TestWaitBackoffAndTimeout(t *testing.T) {
+	clock := NewClock(epoch)
+	recorder := &attemptRecorder{clock: clock}
+
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(errors.New("error")).Run(recorder.record).
+		On("Identity").Return("ID", nil)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- waiter.WaitContext(context.Background(), mockChecker,
+			waiter.WithClock(clock),
+			waiter.WithTimeout(20*time.Second),
+			waiter.WithInterval(time.Second),
+			waiter.WithBackoffPolicy(waiter.BackoffPolicyExponential),
+			waiter.WithBackoffExponentialMaxInterval(5*time.Second),
+		)
+	}()
+
+	for i := 0; i < 20; i++ {
+		// The timeout and the backoff timers
+		clock.BlockUntil(2)
+		clock.Advance(time.Second)
+	}
+
+	err := <-errCh
+	var timeoutError *waiter.TimeoutError
+	if assert.ErrorAs(t, err, &timeoutError) {
+		assert.Equal(t, 6, timeoutError.Attempts)
+	}
+	assert.Equal(t, []time.Duration{0, time.Second, 3 * time.Second, 7 * time.Second, 12 * time.Second, 17 * time.Second}, recorder.times)
+}
+
+func TestWaitSuccessThreshold(t *testing.T) {
+	clock := NewClock(epoch)
+
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- waiter.WaitContext(context.Background(), mockChecker,
+			waiter.WithClock(clock),
+			waiter.WithTimeout(time.Minute),
+			waiter.WithInterval(10*time.Second),
+			waiter.WithSuccessThreshold(3),
+		)
+	}()
+
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(2)
+		clock.Advance(10 * time.Second)
+	}
+
+	assert.Nil(t, <-errCh)
+	assert.Equal(t, epoch.Add(20*time.Second), clock.Now())
+	mockChecker.AssertNumberOfCalls(t, "Check", 3)
+}
+
+func TestWaitAttemptTimeout(t *testing.T) {
+	clock := NewClock(epoch)
+
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(errors.New("error")).Once().Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- waiter.WaitContext(context.Background(), mockChecker,
+			waiter.WithClock(clock),
+			waiter.WithTimeout(2*time.Hour),
+			waiter.WithAttemptTimeout(time.Hour),
+			waiter.WithInterval(time.Second),
+		)
+	}()
+
+	// The timeout and the attempt timeout timers
+	clock.BlockUntil(2)
+	clock.Advance(time.Hour)
+
+	// The timeout and the backoff timers
+	clock.BlockUntil(2)
+	clock.Advance(time.Second)
+
+	assert.Nil(t, <-errCh)
+	mockChecker.AssertNumberOfCalls(t, "Check", 2)
+}
+
+func TestWaitContextDeadline(t *testing.T) {
+	clock := NewClock(epoch)
+
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(errors.New("error")).Run(func(args mock.Arguments) {
+		// The deadline of the fake clock isn't reported, so it's not compared with the system time
+		_, ok := args.Get(0).(context.Context).Deadline()
+		assert.False(t, ok)
+	}).
+		On("Identity").Return("ID", nil)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- waiter.WaitContext(context.Background(), mockChecker,
+			waiter.WithClock(clock),
+			waiter.WithTimeout(time.Second),
+			waiter.WithAttemptTimeout(time.Second),
+		)
+	}()
+
+	// The timeout and the backoff timers
+	clock.BlockUntil(2)
+	clock.Advance(time.Second)
+
+	assert.ErrorIs(t, <-errCh, context.DeadlineExceeded)
+}
@@ -0,0 +1,203 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"wait4x.dev/v3/checker"
+)
+
+// ErrUnhealthy is returned when a watched checker failed more times in a row than the failure threshold.
+var ErrUnhealthy = errors.New("the checker became unhealthy")
+
+// State is the state of a watched checker.
+type State string
+
+const (
+	// StateReady means the last check passed.
+	StateReady State = "ready"
+	// StateDegraded means the last checks failed, but fewer times than the failure threshold.
+	StateDegraded State = "degraded"
+	// StateUnhealthy means the checks failed as many times in a row as the failure threshold.
+	StateUnhealthy State = "unhealthy"
+)
+
+// Transition describes a change of the state of a watched checker.
+type Transition struct {
+	// From is the previous state, empty for the first transition.
+	From State
+	// To is the new state.
+	To State
+	// Failures is the number of consecutive failed checks.
+	Failures int
+	// Err is the error of the last check, nil when the check passed.
+	Err error
+	// Time is the time of the transition.
+	Time time.Time
+}
+
+// Watch waits for all of the checkers to be ready and keeps watching them afterwards.
+func Watch(checkers []checker.Checker, opts ...Option) error {
+	return WatchContext(context.Background(), checkers, opts...)
+}
+
+// WatchContext waits for all of the checkers to be ready, then keeps checking each of them at the
+// interval and reports the state transitions to the observers implementing TransitionObserver.
+// The timeout option only applies to waiting for the checkers to be ready.
+//
+// Watching stops when the context is canceled, then the context error is returned, or when a checker
+// fails as many times in a row as the failure threshold, then the remaining checkers are canceled and
+// a CheckerError wrapping ErrUnhealthy is returned. The unhealthy checkers are reported even when
+// the context is done meanwhile.
+func WatchContext(ctx context.Context, checkers []checker.Checker, opts ...Option) error {
+	options := newOptions(opts...)
+	if options.failureThreshold < 1 {
+		return fmt.Errorf("invalid failure threshold: %d", options.failureThreshold)
+	}
+
+	opts, err := withSharedLimiter(opts, options)
+	if err != nil {
+		return err
+	}
+
+	groupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Every goroutine writes its own error slot, so no synchronization is needed
+	errs := make([]error, len(checkers))
+
+	// stopped reports whether the remaining checkers were canceled on purpose
+	var stopped atomic.Bool
+
+	var wg sync.WaitGroup
+
+	for i, chk := range checkers {
+		wg.Add(1)
+
+		go func(i int, chk checker.Checker) {
+			defer wg.Done()
+
+			// Watching is a part of the waiting, it's reported to the same observers
+			options := newOptions(withOptions(opts, withIndex(i))...)
+			options.observers = options.observers.forWaiting(chk, i)
+
+			err := waitContext(groupCtx, chk, options)
+			if err == nil {
+				err = watch(groupCtx, chk, options)
+			}
+
+			// Ignore the checkers canceled on purpose
+			if errors.Is(err, context.Canceled) && stopped.Load() && ctx.Err() == nil {
+				return
+			}
+
+			errs[i] = newCheckerError(chk, err)
+
+			stopped.Store(true)
+			cancel()
+		}(i, chk)
+	}
+
+	wg.Wait()
+
+	// A checker which became unhealthy is reported even when the context is done meanwhile
+	if ctx.Err() != nil {
+		unhealthy := make([]error, 0, len(errs))
+		for _, err := range errs {
+			if errors.Is(err, ErrUnhealthy) {
+				unhealthy = append(unhealthy, err)
+			}
+		}
+
+		if len(unhealthy) > 0 {
+			return errors.Join(unhealthy...)
+		}
+
+		return ctx.Err()
+	}
+
+	return errors.Join(errs...)
+}
+
+// watch keeps checking a ready checker until the context is canceled or the failure threshold is reached.
+func watch(ctx context.Context, chk checker.Checker, options *options) error {
+	chkName := CheckerName(chk)
+	logger := options.logger.WithValues(checkerLabels(chk)...)
+	chkID, err := chk.Identity()
+	if err != nil {
+		return err
+	}
+
+	state := StateReady
+	failures := 0
+	transit := func(to State, err error) {
+		options.observers.OnTransition(chk, Transition{From: state, To: to, Failures: failures, Err: err, Time: options.clock.Now()})
+		state = to
+	}
+
+	logger.Info(fmt.Sprintf("[%s] Watching the %s ...", chkName, chkID))
+	options.observers.OnTransition(chk, Transition{To: StateReady, Time: options.clock.Now()})
+
+	for {
+		if err := options.sleep(ctx, options.interval); err != nil {
+			return err
+		}
+
+		if err := options.limiter.acquire(ctx); err != nil {
+			return err
+		}
+		err := check(ctx, chk, options)
+		options.limiter.release()
+
+		// A check interrupted by the cancellation isn't a failure
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if (err == nil) != options.invertCheck {
+			if state != StateReady {
+				logger.Info(fmt.Sprintf("[%s] The %s recovered", chkName, chkID))
+				failures = 0
+				transit(StateReady, nil)
+			}
+
+			continue
+		}
+
+		failures++
+
+		if failures >= options.failureThreshold {
+			logger.Info(fmt.Sprintf("[%s] The %s became unhealthy", chkName, chkID), "failures", failures)
+			transit(StateUnhealthy, err)
+
+			if err != nil {
+				return fmt.Errorf("%w after %d failed checks: %w", ErrUnhealthy, failures, err)
+			}
+
+			return fmt.Errorf("%w after %d failed checks", ErrUnhealthy, failures)
+		}
+
+		if state == StateReady {
+			logger.Info(fmt.Sprintf("[%s] The %s is degraded", chkName, chkID), "failures", failures)
+			transit(StateDegraded, err)
+		}
+	}
+}
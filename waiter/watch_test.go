@@ -0,0 +1,133 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"wait4x.dev/v3/checker"
+)
+
+// transitionRecorder records the state transitions and calls onTransition after each of them.
+type transitionRecorder struct {
+	NopObserver
+	mu           sync.Mutex
+	transitions  []string
+	onTransition func(Transition)
+}
+
+func (tr *transitionRecorder) OnTransition(_ checker.Checker, transition Transition) {
+	tr.mu.Lock()
+	tr.transitions = append(tr.transitions, fmt.Sprintf("%s->%s", transition.From, transition.To))
+	tr.mu.Unlock()
+
+	if tr.onTransition != nil {
+		tr.onTransition(transition)
+	}
+}
+
+func TestWatchUnhealthy(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	failing := new(checker.MockChecker)
+	failing.On("Check", mock.Anything).Return(nil).Twice().
+		On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("failing", nil)
+
+	healthy := new(checker.MockChecker)
+	healthy.On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("healthy", nil)
+
+	recorder := new(transitionRecorder)
+	err := Watch(
+		[]checker.Checker{failing, healthy},
+		WithInterval(10*time.Millisecond),
+		WithFailureThreshold(2),
+		WithObserver(recorder),
+	)
+	assert.ErrorIs(t, err, ErrUnhealthy)
+	assert.Equal(t, "failing: the checker became unhealthy after 2 failed checks: error", err.Error())
+	assert.Contains(t, recorder.transitions, "ready->degraded")
+	assert.Contains(t, recorder.transitions, "degraded->unhealthy")
+	failing.AssertNumberOfCalls(t, "Check", 4)
+
+	assertNoGoroutineLeak(t, baseline)
+}
+
+func TestWatchRecovered(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(nil).Twice().
+		On("Check", mock.Anything).Return(fmt.Errorf("error")).Once().
+		On("Check", mock.Anything).Return(nil).
+		On("Identity").Return("ID", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	recorder := &transitionRecorder{onTransition: func(transition Transition) {
+		if transition.From == StateDegraded {
+			cancel()
+		}
+	}}
+	err := WatchContext(ctx, []checker.Checker{mockChecker}, WithInterval(10*time.Millisecond), WithFailureThreshold(3), WithObserver(recorder))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"->ready", "ready->degraded", "degraded->ready"}, recorder.transitions)
+}
+
+func TestWatchUnhealthyThenCanceled(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(nil).Once().
+		On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("ID", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The context is done right after the checker became unhealthy
+	recorder := &transitionRecorder{onTransition: func(transition Transition) {
+		if transition.To == StateUnhealthy {
+			cancel()
+		}
+	}}
+	err := WatchContext(ctx, []checker.Checker{mockChecker}, WithInterval(10*time.Millisecond), WithObserver(recorder))
+	assert.ErrorIs(t, err, ErrUnhealthy)
+	assert.NotErrorIs(t, err, context.Canceled)
+}
+
+func TestWatchNotReady(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).Return(fmt.Errorf("error")).
+		On("Identity").Return("ID", nil)
+
+	recorder := new(transitionRecorder)
+	err := Watch([]checker.Checker{mockChecker}, WithTimeout(50*time.Millisecond), WithInterval(10*time.Millisecond), WithObserver(recorder))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, recorder.transitions)
+}
+
+func TestWatchInvalidFailureThreshold(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+
+	err := Watch([]checker.Checker{mockChecker}, WithFailureThreshold(0))
+	assert.EqualError(t, err, "invalid failure threshold: 0")
+	mockChecker.AssertNotCalled(t, "Check", mock.Anything)
+}